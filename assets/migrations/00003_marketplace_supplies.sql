@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_supplies (
+    supply_id varchar(32) NOT NULL,
+    name varchar(128) NOT NULL,
+    done boolean DEFAULT false,
+    created_at timestamp,
+    closed_at timestamp,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (supply_id)
+);
+
+CREATE TABLE IF NOT EXISTS wb_supply_orders (
+    order_id bigint NOT NULL,
+    supply_id varchar(32) NOT NULL,
+    nm_id int NOT NULL,
+    sku varchar(16),
+    article varchar(64),
+    created_at timestamp,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (order_id),
+    FOREIGN KEY (supply_id) REFERENCES wb_supplies (supply_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wb_supply_orders
+    ADD COLUMN IF NOT EXISTS sticker_barcode varchar(32),
+    ADD COLUMN IF NOT EXISTS sticker_part_a bigint,
+    ADD COLUMN IF NOT EXISTS sticker_part_b bigint;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wb_content_cards
+    ADD COLUMN IF NOT EXISTS kiz_required boolean DEFAULT false;
+
+ALTER TABLE wb_supply_orders
+    ADD COLUMN IF NOT EXISTS required_meta varchar(16)[],
+    ADD COLUMN IF NOT EXISTS canceled boolean DEFAULT false;
+-- +goose StatementEnd
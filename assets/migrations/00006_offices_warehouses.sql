@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_offices (
+    office_id int NOT NULL,
+    name varchar(128) NOT NULL,
+    address varchar(256),
+    city varchar(128),
+    longitude double precision,
+    latitude double precision,
+    cargo_type int,
+    delivery_type int,
+    selected boolean DEFAULT false,
+    deleted boolean DEFAULT false,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (office_id)
+);
+
+CREATE TABLE IF NOT EXISTS wb_warehouses (
+    warehouse_id int NOT NULL,
+    name varchar(128) NOT NULL,
+    office_id int NOT NULL,
+    cargo_type int,
+    delivery_type int,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (warehouse_id)
+);
+-- +goose StatementEnd
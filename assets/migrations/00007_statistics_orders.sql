@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_orders (
+    srid varchar(64) NOT NULL,
+    g_number varchar(64),
+    date timestamp NOT NULL,
+    last_change_date timestamp NOT NULL,
+    warehouse_name varchar(128),
+    warehouse_type varchar(64),
+    country_name varchar(128),
+    oblast_okrug_name varchar(128),
+    region_name varchar(128),
+    supplier_article varchar(128),
+    nm_id int NOT NULL,
+    barcode varchar(32),
+    category varchar(128),
+    subject varchar(128),
+    brand varchar(128),
+    tech_size varchar(64),
+    income_id int,
+    is_supply boolean,
+    is_realization boolean,
+    total_price numeric(12, 2),
+    discount_percent int,
+    spp numeric(12, 2),
+    finished_price numeric(12, 2),
+    price_with_disc numeric(12, 2),
+    is_cancel boolean DEFAULT false,
+    cancel_date timestamp,
+    order_type varchar(64),
+    sticker varchar(64),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (srid)
+);
+
+CREATE INDEX IF NOT EXISTS wb_orders_nm_id_idx ON wb_orders (nm_id);
+CREATE INDEX IF NOT EXISTS wb_orders_last_change_date_idx ON wb_orders (last_change_date);
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_sales (
+    sale_id varchar(32) NOT NULL,
+    srid varchar(64),
+    g_number varchar(64),
+    date timestamp NOT NULL,
+    last_change_date timestamp NOT NULL,
+    warehouse_name varchar(128),
+    warehouse_type varchar(64),
+    country_name varchar(128),
+    oblast_okrug_name varchar(128),
+    region_name varchar(128),
+    supplier_article varchar(128),
+    nm_id int NOT NULL,
+    barcode varchar(32),
+    category varchar(128),
+    subject varchar(128),
+    brand varchar(128),
+    tech_size varchar(64),
+    income_id int,
+    is_supply boolean,
+    is_realization boolean,
+    total_price numeric(12, 2),
+    discount_percent int,
+    spp numeric(12, 2),
+    payment_sale_amount numeric(12, 2),
+    for_pay numeric(12, 2),
+    finished_price numeric(12, 2),
+    price_with_disc numeric(12, 2),
+    is_return boolean GENERATED ALWAYS AS (sale_id LIKE 'R%') STORED,
+    order_type varchar(64),
+    sticker varchar(64),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (sale_id)
+);
+
+CREATE INDEX IF NOT EXISTS wb_sales_nm_id_idx ON wb_sales (nm_id);
+CREATE INDEX IF NOT EXISTS wb_sales_last_change_date_idx ON wb_sales (last_change_date);
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_incomes (
+    income_id int NOT NULL,
+    barcode varchar(32) NOT NULL,
+    number varchar(64),
+    date timestamp NOT NULL,
+    last_change_date timestamp NOT NULL,
+    supplier_article varchar(128),
+    tech_size varchar(64),
+    nm_id int NOT NULL,
+    quantity int NOT NULL,
+    total_price numeric(12, 2),
+    date_close timestamp,
+    warehouse_name varchar(128),
+    status varchar(64),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (income_id, barcode)
+);
+
+CREATE INDEX IF NOT EXISTS wb_incomes_nm_id_idx ON wb_incomes (nm_id);
+CREATE INDEX IF NOT EXISTS wb_incomes_last_change_date_idx ON wb_incomes (last_change_date);
+-- +goose StatementEnd
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_realization_report (
+    rrd_id bigint NOT NULL,
+    realizationreport_id bigint NOT NULL,
+    date_from date,
+    date_to date,
+    create_dt timestamp,
+    currency_name varchar(16),
+    gi_id bigint,
+    subject_name varchar(128),
+    nm_id int,
+    brand_name varchar(128),
+    sa_name varchar(128),
+    ts_name varchar(64),
+    barcode varchar(32),
+    doc_type_name varchar(64),
+    quantity int,
+    retail_price numeric(12, 2),
+    retail_amount numeric(12, 2),
+    sale_percent numeric(6, 2),
+    commission_percent numeric(6, 2),
+    office_name varchar(128),
+    supplier_oper_name varchar(128),
+    order_dt timestamp,
+    sale_dt timestamp,
+    rr_dt timestamp,
+    shk_id bigint,
+    retail_price_withdisc_rub numeric(12, 2),
+    delivery_amount int,
+    return_amount int,
+    delivery_rub numeric(12, 2),
+    gi_box_type_name varchar(64),
+    product_discount_for_report numeric(12, 2),
+    supplier_promo numeric(12, 2),
+    ppvz_spp_prc numeric(6, 2),
+    ppvz_kvw_prc_base numeric(6, 2),
+    ppvz_kvw_prc numeric(6, 2),
+    ppvz_sales_commission numeric(12, 2),
+    ppvz_for_pay numeric(12, 2),
+    ppvz_reward numeric(12, 2),
+    acquiring_fee numeric(12, 2),
+    ppvz_vw numeric(12, 2),
+    ppvz_vw_nds numeric(12, 2),
+    ppvz_office_id bigint,
+    bonus_type_name varchar(256),
+    sticker_id varchar(64),
+    site_country varchar(64),
+    penalty numeric(12, 2),
+    additional_payment numeric(12, 2),
+    rebill_logistic_cost numeric(12, 2),
+    storage_fee numeric(12, 2),
+    deduction numeric(12, 2),
+    acceptance numeric(12, 2),
+    srid varchar(64),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (rrd_id)
+);
+
+CREATE INDEX IF NOT EXISTS wb_realization_report_nm_id_idx ON wb_realization_report (nm_id);
+CREATE INDEX IF NOT EXISTS wb_realization_report_report_id_idx ON wb_realization_report (realizationreport_id);
+-- +goose StatementEnd
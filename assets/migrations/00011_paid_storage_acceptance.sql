@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_paid_storage (
+    date date NOT NULL,
+    nm_id int NOT NULL,
+    barcode varchar(32),
+    chrt_id bigint,
+    gi_id bigint,
+    vendor_code varchar(128),
+    subject varchar(128),
+    brand varchar(128),
+    size varchar(64),
+    office_id int,
+    warehouse varchar(128),
+    warehouse_coef numeric(8, 2),
+    log_warehouse_coef numeric(8, 2),
+    volume numeric(12, 3),
+    calc_type varchar(128),
+    warehouse_price numeric(12, 2) NOT NULL,
+    barcodes_count int,
+    pallet_place_code int,
+    pallet_count numeric(12, 3),
+    loyalty_discount numeric(12, 2),
+    updated_timestamp timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wb_paid_storage_date_idx ON wb_paid_storage (date);
+CREATE INDEX IF NOT EXISTS wb_paid_storage_nm_id_idx ON wb_paid_storage (nm_id);
+
+CREATE OR REPLACE VIEW wb_paid_storage_daily AS
+    SELECT date, nm_id, sum(warehouse_price) as warehouse_price, sum(barcodes_count) as barcodes_count
+    FROM wb_paid_storage
+    GROUP BY date, nm_id;
+
+CREATE TABLE IF NOT EXISTS wb_paid_acceptance (
+    income_id bigint NOT NULL,
+    nm_id int NOT NULL,
+    subject_name varchar(128),
+    gi_create_date date,
+    shk_create_date date NOT NULL,
+    count int NOT NULL,
+    total numeric(12, 2) NOT NULL,
+    updated_timestamp timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wb_paid_acceptance_shk_create_date_idx ON wb_paid_acceptance (shk_create_date);
+CREATE INDEX IF NOT EXISTS wb_paid_acceptance_nm_id_idx ON wb_paid_acceptance (nm_id);
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_nm_report_history (
+    nm_id int NOT NULL,
+    date date NOT NULL,
+    open_card_count int NOT NULL,
+    add_to_cart_count int NOT NULL,
+    add_to_cart_conversion numeric(6, 2),
+    orders_count int NOT NULL,
+    orders_sum_rub numeric(12, 2),
+    cart_to_order_conversion numeric(6, 2),
+    buyouts_count int NOT NULL,
+    buyouts_sum_rub numeric(12, 2),
+    buyout_percent numeric(6, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (nm_id, date)
+);
+-- +goose StatementEnd
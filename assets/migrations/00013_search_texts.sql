@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_search_texts (
+    nm_id int NOT NULL,
+    week date NOT NULL,
+    text varchar(256) NOT NULL,
+    frequency int,
+    week_frequency int,
+    avg_position numeric(10, 2),
+    median_position numeric(10, 2),
+    open_card int,
+    add_to_cart int,
+    orders int,
+    visibility numeric(6, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (nm_id, week, text)
+);
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_feedbacks (
+    feedback_id varchar(32) PRIMARY KEY,
+    nm_id int NOT NULL,
+    imt_id int,
+    supplier_article varchar(128),
+    product_name varchar(256),
+    brand_name varchar(128),
+    subject_name varchar(128),
+    size varchar(64),
+    user_name varchar(128),
+    text text,
+    pros text,
+    cons text,
+    product_valuation smallint,
+    created_date timestamp NOT NULL,
+    state varchar(32),
+    was_viewed boolean NOT NULL DEFAULT false,
+    answered boolean NOT NULL DEFAULT false,
+    answer_text text,
+    answer_editable boolean,
+    updated_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_feedbacks_nm_id_idx ON wb_feedbacks (nm_id);
+
+CREATE TABLE IF NOT EXISTS wb_questions (
+    question_id varchar(32) PRIMARY KEY,
+    nm_id int NOT NULL,
+    imt_id int,
+    supplier_article varchar(128),
+    product_name varchar(256),
+    brand_name varchar(128),
+    size varchar(64),
+    text text,
+    created_date timestamp NOT NULL,
+    state varchar(32),
+    was_viewed boolean NOT NULL DEFAULT false,
+    answered boolean NOT NULL DEFAULT false,
+    answer_text text,
+    answer_editable boolean,
+    updated_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_questions_nm_id_idx ON wb_questions (nm_id);
+
+CREATE OR REPLACE VIEW wb_feedbacks_unanswered AS
+    SELECT 'feedback' AS kind, feedback_id AS id, nm_id, supplier_article, product_name, product_valuation,
+        text, created_date, (now() AT TIME ZONE 'UTC') - created_date AS waiting
+    FROM wb_feedbacks WHERE NOT answered
+    UNION ALL
+    SELECT 'question' AS kind, question_id AS id, nm_id, supplier_article, product_name, NULL AS product_valuation,
+        text, created_date, (now() AT TIME ZONE 'UTC') - created_date AS waiting
+    FROM wb_questions WHERE NOT answered;
+-- +goose StatementEnd
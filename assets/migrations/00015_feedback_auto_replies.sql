@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_feedback_auto_replies (
+    id serial PRIMARY KEY,
+    feedback_id varchar(32) NOT NULL,
+    nm_id int NOT NULL,
+    product_valuation smallint,
+    rule varchar(64) NOT NULL,
+    text text NOT NULL,
+    dry_run boolean NOT NULL DEFAULT false,
+    created_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_feedback_auto_replies_created_idx ON wb_feedback_auto_replies (created_timestamp);
+-- +goose StatementEnd
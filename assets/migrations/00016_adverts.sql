@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_adverts (
+    advert_id int PRIMARY KEY,
+    name varchar(256),
+    type smallint NOT NULL,
+    status smallint NOT NULL,
+    daily_budget numeric(12, 2),
+    budget numeric(12, 2),
+    payment_type varchar(16),
+    create_time timestamp,
+    change_time timestamp,
+    start_time timestamp,
+    end_time timestamp,
+    updated_timestamp timestamp NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS wb_advert_nms (
+    advert_id int NOT NULL,
+    nm_id int NOT NULL,
+    PRIMARY KEY (advert_id, nm_id),
+    FOREIGN KEY (advert_id) REFERENCES wb_adverts (advert_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS wb_advert_nms_nm_id_idx ON wb_advert_nms (nm_id);
+
+CREATE TABLE IF NOT EXISTS wb_advert_stats (
+    advert_id int NOT NULL,
+    nm_id int NOT NULL,
+    date date NOT NULL,
+    views int NOT NULL,
+    clicks int NOT NULL,
+    sum numeric(12, 2) NOT NULL,
+    atbs int NOT NULL,
+    orders int NOT NULL,
+    shks int NOT NULL,
+    sum_price numeric(12, 2) NOT NULL,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (advert_id, nm_id, date)
+);
+CREATE INDEX IF NOT EXISTS wb_advert_stats_nm_id_idx ON wb_advert_stats (nm_id, date);
+
+CREATE TABLE IF NOT EXISTS wb_advert_balance (
+    date date PRIMARY KEY,
+    balance numeric(12, 2) NOT NULL,
+    net numeric(12, 2) NOT NULL,
+    bonus numeric(12, 2) NOT NULL,
+    updated_timestamp timestamp NOT NULL
+);
+-- +goose StatementEnd
@@ -0,0 +1,4 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wb_adverts ADD COLUMN IF NOT EXISTS paused_by_stock boolean NOT NULL DEFAULT false;
+-- +goose StatementEnd
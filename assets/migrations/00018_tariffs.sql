@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_tariffs_box (
+    date date NOT NULL,
+    warehouse_name varchar(128) NOT NULL,
+    geo_name varchar(128),
+    delivery_and_storage_expr numeric(10, 2),
+    delivery_base numeric(10, 2),
+    delivery_liter numeric(10, 2),
+    storage_base numeric(10, 2),
+    storage_liter numeric(10, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (date, warehouse_name)
+);
+
+CREATE TABLE IF NOT EXISTS wb_tariffs_pallet (
+    date date NOT NULL,
+    warehouse_name varchar(128) NOT NULL,
+    delivery_expr numeric(10, 2),
+    delivery_base numeric(10, 2),
+    delivery_liter numeric(10, 2),
+    storage_expr numeric(10, 2),
+    storage_value_expr numeric(10, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (date, warehouse_name)
+);
+
+CREATE TABLE IF NOT EXISTS wb_tariffs_return (
+    date date NOT NULL,
+    warehouse_name varchar(128) NOT NULL,
+    kgt_office_base numeric(10, 2),
+    kgt_office_liter numeric(10, 2),
+    kgt_return_expr numeric(10, 2),
+    srg_office_expr numeric(10, 2),
+    srg_return_expr numeric(10, 2),
+    sup_courier_base numeric(10, 2),
+    sup_courier_liter numeric(10, 2),
+    sup_office_base numeric(10, 2),
+    sup_office_liter numeric(10, 2),
+    sup_return_expr numeric(10, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (date, warehouse_name)
+);
+
+CREATE TABLE IF NOT EXISTS wb_commissions (
+    date date NOT NULL,
+    subject_id int NOT NULL,
+    subject_name varchar(128),
+    parent_id int,
+    parent_name varchar(128),
+    kgvp_marketplace numeric(6, 2),
+    kgvp_supplier numeric(6, 2),
+    kgvp_supplier_express numeric(6, 2),
+    paid_storage_kgvp numeric(6, 2),
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (date, subject_id)
+);
+
+CREATE OR REPLACE VIEW wb_content_cards_commission AS
+    SELECT c.nm_id, c.vendor_code, c.subject_id, c.subject_name, m.date, m.kgvp_marketplace, m.kgvp_supplier,
+        m.kgvp_supplier_express, m.paid_storage_kgvp
+    FROM wb_content_cards c
+    JOIN wb_commissions m ON m.subject_id = c.subject_id
+    WHERE m.date = (SELECT max(date) FROM wb_commissions);
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_acceptance_slots (
+    warehouse_id int NOT NULL,
+    box_type_id smallint NOT NULL,
+    date date NOT NULL,
+    warehouse_name varchar(128),
+    box_type_name varchar(64),
+    coefficient numeric(6, 2) NOT NULL,
+    allow_unload boolean NOT NULL,
+    matched boolean NOT NULL DEFAULT false,
+    notified_timestamp timestamp,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (warehouse_id, box_type_id, date)
+);
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_claims (
+    claim_id varchar(64) PRIMARY KEY,
+    claim_type smallint NOT NULL,
+    status smallint NOT NULL,
+    status_ex smallint NOT NULL,
+    nm_id int NOT NULL,
+    sku varchar(32),
+    srid varchar(64),
+    imt_name varchar(256),
+    user_comment text,
+    wb_comment text,
+    price numeric(12, 2),
+    currency_code varchar(8),
+    photos text[],
+    video_paths text[],
+    actions varchar(32)[],
+    archived boolean NOT NULL DEFAULT false,
+    answer_action varchar(32),
+    answer_comment text,
+    dt timestamp NOT NULL,
+    order_dt timestamp,
+    dt_update timestamp,
+    updated_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_claims_nm_id_idx ON wb_claims (nm_id);
+
+CREATE OR REPLACE VIEW wb_content_cards_returns AS
+    SELECT c.nm_id, c.vendor_code,
+        (SELECT count(*) FROM wb_orders o WHERE o.nm_id = c.nm_id AND o.date >= now() - interval '30 days') AS orders_30d,
+        (SELECT count(*) FROM wb_sales s WHERE s.nm_id = c.nm_id AND s.is_return AND s.date >= now() - interval '30 days') AS returns_30d,
+        (SELECT count(*) FROM wb_claims l WHERE l.nm_id = c.nm_id AND l.dt >= now() - interval '30 days') AS claims_30d,
+        (SELECT coalesce(sum(t.in_way_from_client), 0) FROM wb_stocks t WHERE t.nm_id = c.nm_id) AS in_way_from_client
+    FROM wb_content_cards c
+    WHERE NOT c.deleted;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_chats (
+    chat_id varchar(128) PRIMARY KEY,
+    reply_sign text NOT NULL,
+    client_id varchar(64),
+    client_name varchar(128),
+    nm_id int,
+    rid varchar(64),
+    last_message_text text,
+    last_message_time timestamp,
+    updated_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_chats_nm_id_idx ON wb_chats (nm_id);
+
+CREATE TABLE IF NOT EXISTS wb_chat_messages (
+    event_id varchar(128) PRIMARY KEY,
+    chat_id varchar(128) NOT NULL,
+    event_type varchar(32),
+    sender varchar(16),
+    source varchar(32),
+    client_name varchar(128),
+    nm_id int,
+    text text,
+    add_timestamp bigint NOT NULL,
+    add_time timestamp NOT NULL,
+    updated_timestamp timestamp NOT NULL
+);
+CREATE INDEX IF NOT EXISTS wb_chat_messages_chat_id_idx ON wb_chat_messages (chat_id, add_time);
+
+CREATE TABLE IF NOT EXISTS wb_chat_attachments (
+    event_id varchar(128) NOT NULL,
+    download_id varchar(256) NOT NULL,
+    kind varchar(8) NOT NULL,
+    name varchar(256),
+    content_type varchar(128),
+    size bigint,
+    url text,
+    local_path text,
+    PRIMARY KEY (event_id, download_id),
+    FOREIGN KEY (event_id) REFERENCES wb_chat_messages (event_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
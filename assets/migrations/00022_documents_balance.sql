@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_documents (
+    service_name varchar(256) NOT NULL,
+    extension varchar(16) NOT NULL,
+    name varchar(256),
+    category varchar(64),
+    creation_time timestamp,
+    viewed boolean NOT NULL DEFAULT false,
+    local_path text,
+    downloaded_timestamp timestamp,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (service_name, extension)
+);
+CREATE INDEX IF NOT EXISTS wb_documents_category_idx ON wb_documents (category, creation_time);
+
+CREATE TABLE IF NOT EXISTS wb_balance (
+    date date PRIMARY KEY,
+    currency varchar(8) NOT NULL,
+    current numeric(14, 2) NOT NULL,
+    for_withdraw numeric(14, 2) NOT NULL,
+    updated_timestamp timestamp NOT NULL
+);
+-- +goose StatementEnd
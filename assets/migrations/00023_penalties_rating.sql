@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_penalties (
+    kind varchar(32) NOT NULL,
+    penalty_id varchar(64) NOT NULL,
+    nm_id int NOT NULL,
+    date timestamp NOT NULL,
+    amount numeric(12, 2) NOT NULL,
+    reason text,
+    created_timestamp timestamp NOT NULL,
+    PRIMARY KEY (kind, penalty_id)
+);
+CREATE INDEX IF NOT EXISTS wb_penalties_nm_id_idx ON wb_penalties (nm_id, date);
+
+CREATE TABLE IF NOT EXISTS wb_seller_rating (
+    date date PRIMARY KEY,
+    valuation numeric(4, 2),
+    feedbacks_unanswered int NOT NULL,
+    questions_unanswered int NOT NULL,
+    updated_timestamp timestamp NOT NULL
+);
+-- +goose StatementEnd
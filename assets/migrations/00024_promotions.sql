@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE wb_content_cards ADD COLUMN IF NOT EXISTS cost_price numeric(12, 2);
+
+CREATE TABLE IF NOT EXISTS wb_promotions (
+    promotion_id int NOT NULL,
+    name varchar(256) NOT NULL,
+    type varchar(32) NOT NULL,
+    description text,
+    start_timestamp timestamp NOT NULL,
+    end_timestamp timestamp NOT NULL,
+    in_action_total int,
+    not_in_action_total int,
+    participation_percentage numeric(6, 2),
+    notified boolean DEFAULT false,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (promotion_id)
+);
+
+CREATE TABLE IF NOT EXISTS wb_promotion_uploads (
+    upload_id bigint NOT NULL,
+    promotion_id int NOT NULL,
+    nm_ids int[] NOT NULL,
+    created_timestamp timestamp NOT NULL,
+    PRIMARY KEY (upload_id),
+    FOREIGN KEY (promotion_id) REFERENCES wb_promotions (promotion_id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
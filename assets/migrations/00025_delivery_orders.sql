@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_delivery_orders (
+    order_id bigint NOT NULL,
+    flow varchar(16) NOT NULL,
+    rid varchar(64),
+    order_uid varchar(64),
+    group_id varchar(64),
+    nm_id int NOT NULL,
+    sku varchar(16),
+    article varchar(64),
+    warehouse_id int,
+    price int,
+    converted_price int,
+    currency_code int,
+    address text,
+    comment text,
+    delivery_date varchar(16),
+    delivery_time_from varchar(8),
+    delivery_time_to varchar(8),
+    supplier_status varchar(32) NOT NULL,
+    wb_status varchar(32),
+    created_at timestamp,
+    updated_timestamp timestamp NOT NULL,
+    PRIMARY KEY (order_id)
+);
+CREATE INDEX IF NOT EXISTS wb_delivery_orders_status_idx ON wb_delivery_orders (flow, supplier_status);
+
+CREATE TABLE IF NOT EXISTS wb_delivery_order_statuses (
+    order_id bigint NOT NULL,
+    supplier_status varchar(32) NOT NULL,
+    wb_status varchar(32),
+    changed_timestamp timestamp NOT NULL,
+    FOREIGN KEY (order_id) REFERENCES wb_delivery_orders (order_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS wb_delivery_order_statuses_order_id_idx ON wb_delivery_order_statuses (order_id, changed_timestamp);
+-- +goose StatementEnd
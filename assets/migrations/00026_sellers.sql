@@ -0,0 +1,138 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS wb_sellers (
+    seller_id serial PRIMARY KEY,
+    name varchar(64) NOT NULL UNIQUE,
+    token_env varchar(128),
+    created_timestamp timestamp NOT NULL
+);
+
+-- Данные, загруженные до появления кабинетов, принадлежат кабинету default
+INSERT INTO wb_sellers (seller_id, name, created_timestamp) VALUES (1, 'default', now() AT TIME ZONE 'UTC')
+    ON CONFLICT DO NOTHING;
+SELECT setval(pg_get_serial_sequence('wb_sellers', 'seller_id'), (SELECT max(seller_id) FROM wb_sellers));
+
+DO $$
+DECLARE
+    t text;
+BEGIN
+    FOREACH t IN ARRAY ARRAY[
+        'wb_content_cards', 'wb_content_skus', 'wb_marketplace_stocks', 'wb_stocks', 'wb_supplies',
+        'wb_supply_orders', 'wb_offices', 'wb_warehouses', 'wb_orders', 'wb_sales', 'wb_incomes',
+        'wb_realization_report', 'wb_paid_storage', 'wb_paid_acceptance', 'wb_nm_report_history',
+        'wb_search_texts', 'wb_feedbacks', 'wb_questions', 'wb_feedback_auto_replies', 'wb_adverts',
+        'wb_advert_nms', 'wb_advert_stats', 'wb_advert_balance', 'wb_tariffs_box', 'wb_tariffs_pallet',
+        'wb_tariffs_return', 'wb_commissions', 'wb_acceptance_slots', 'wb_claims', 'wb_chats',
+        'wb_chat_messages', 'wb_chat_attachments', 'wb_documents', 'wb_balance', 'wb_penalties',
+        'wb_seller_rating', 'wb_promotions', 'wb_promotion_uploads', 'wb_delivery_orders',
+        'wb_delivery_order_statuses'
+    ] LOOP
+        EXECUTE format(
+            'ALTER TABLE %I ADD COLUMN IF NOT EXISTS seller_id int NOT NULL DEFAULT 1 REFERENCES wb_sellers (seller_id)', t
+        );
+        EXECUTE format('ALTER TABLE %I ALTER COLUMN seller_id DROP DEFAULT', t);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I (seller_id)', t || '_seller_id_idx', t);
+    END LOOP;
+END $$;
+
+-- Артикулы, баркоды и другие ключи уникальны только в пределах кабинета: кабинеты одной группы
+-- могут использовать одинаковые баркоды производителя, поэтому seller_id входит в первичные ключи.
+-- Внешние ключи удаляются до изменения первичных ключей и создаются заново с seller_id
+ALTER TABLE wb_content_skus DROP CONSTRAINT wb_content_skus_nm_id_fkey;
+ALTER TABLE wb_marketplace_stocks DROP CONSTRAINT wb_marketplace_stocks_nm_id_fkey;
+ALTER TABLE wb_stocks DROP CONSTRAINT wb_stocks_nm_id_fkey;
+ALTER TABLE wb_supply_orders DROP CONSTRAINT wb_supply_orders_supply_id_fkey;
+ALTER TABLE wb_advert_nms DROP CONSTRAINT wb_advert_nms_advert_id_fkey;
+ALTER TABLE wb_chat_attachments DROP CONSTRAINT wb_chat_attachments_event_id_fkey;
+ALTER TABLE wb_promotion_uploads DROP CONSTRAINT wb_promotion_uploads_promotion_id_fkey;
+ALTER TABLE wb_delivery_order_statuses DROP CONSTRAINT wb_delivery_order_statuses_order_id_fkey;
+
+ALTER TABLE wb_content_cards DROP CONSTRAINT wb_content_cards_pkey, ADD PRIMARY KEY (seller_id, nm_id);
+ALTER TABLE wb_content_skus DROP CONSTRAINT wb_content_skus_pkey, ADD PRIMARY KEY (seller_id, sku);
+ALTER TABLE wb_marketplace_stocks DROP CONSTRAINT wb_marketplace_stocks_pkey, ADD PRIMARY KEY (seller_id, sku);
+ALTER TABLE wb_stocks DROP CONSTRAINT wb_stocks_pkey, ADD PRIMARY KEY (seller_id, sku);
+ALTER TABLE wb_supplies DROP CONSTRAINT wb_supplies_pkey, ADD PRIMARY KEY (seller_id, supply_id);
+ALTER TABLE wb_supply_orders DROP CONSTRAINT wb_supply_orders_pkey, ADD PRIMARY KEY (seller_id, order_id);
+ALTER TABLE wb_offices DROP CONSTRAINT wb_offices_pkey, ADD PRIMARY KEY (seller_id, office_id);
+ALTER TABLE wb_warehouses DROP CONSTRAINT wb_warehouses_pkey, ADD PRIMARY KEY (seller_id, warehouse_id);
+ALTER TABLE wb_orders DROP CONSTRAINT wb_orders_pkey, ADD PRIMARY KEY (seller_id, srid);
+ALTER TABLE wb_sales DROP CONSTRAINT wb_sales_pkey, ADD PRIMARY KEY (seller_id, sale_id);
+ALTER TABLE wb_incomes DROP CONSTRAINT wb_incomes_pkey, ADD PRIMARY KEY (seller_id, income_id, barcode);
+ALTER TABLE wb_realization_report DROP CONSTRAINT wb_realization_report_pkey, ADD PRIMARY KEY (seller_id, rrd_id);
+ALTER TABLE wb_nm_report_history DROP CONSTRAINT wb_nm_report_history_pkey, ADD PRIMARY KEY (seller_id, nm_id, date);
+ALTER TABLE wb_search_texts DROP CONSTRAINT wb_search_texts_pkey, ADD PRIMARY KEY (seller_id, nm_id, week, text);
+ALTER TABLE wb_feedbacks DROP CONSTRAINT wb_feedbacks_pkey, ADD PRIMARY KEY (seller_id, feedback_id);
+ALTER TABLE wb_questions DROP CONSTRAINT wb_questions_pkey, ADD PRIMARY KEY (seller_id, question_id);
+ALTER TABLE wb_adverts DROP CONSTRAINT wb_adverts_pkey, ADD PRIMARY KEY (seller_id, advert_id);
+ALTER TABLE wb_advert_nms DROP CONSTRAINT wb_advert_nms_pkey, ADD PRIMARY KEY (seller_id, advert_id, nm_id);
+ALTER TABLE wb_advert_stats DROP CONSTRAINT wb_advert_stats_pkey, ADD PRIMARY KEY (seller_id, advert_id, nm_id, date);
+ALTER TABLE wb_advert_balance DROP CONSTRAINT wb_advert_balance_pkey, ADD PRIMARY KEY (seller_id, date);
+ALTER TABLE wb_tariffs_box DROP CONSTRAINT wb_tariffs_box_pkey, ADD PRIMARY KEY (seller_id, date, warehouse_name);
+ALTER TABLE wb_tariffs_pallet DROP CONSTRAINT wb_tariffs_pallet_pkey, ADD PRIMARY KEY (seller_id, date, warehouse_name);
+ALTER TABLE wb_tariffs_return DROP CONSTRAINT wb_tariffs_return_pkey, ADD PRIMARY KEY (seller_id, date, warehouse_name);
+ALTER TABLE wb_commissions DROP CONSTRAINT wb_commissions_pkey, ADD PRIMARY KEY (seller_id, date, subject_id);
+ALTER TABLE wb_acceptance_slots DROP CONSTRAINT wb_acceptance_slots_pkey,
+    ADD PRIMARY KEY (seller_id, warehouse_id, box_type_id, date);
+ALTER TABLE wb_claims DROP CONSTRAINT wb_claims_pkey, ADD PRIMARY KEY (seller_id, claim_id);
+ALTER TABLE wb_chats DROP CONSTRAINT wb_chats_pkey, ADD PRIMARY KEY (seller_id, chat_id);
+ALTER TABLE wb_chat_messages DROP CONSTRAINT wb_chat_messages_pkey, ADD PRIMARY KEY (seller_id, event_id);
+ALTER TABLE wb_chat_attachments DROP CONSTRAINT wb_chat_attachments_pkey, ADD PRIMARY KEY (seller_id, event_id, download_id);
+ALTER TABLE wb_documents DROP CONSTRAINT wb_documents_pkey, ADD PRIMARY KEY (seller_id, service_name, extension);
+ALTER TABLE wb_balance DROP CONSTRAINT wb_balance_pkey, ADD PRIMARY KEY (seller_id, date);
+ALTER TABLE wb_penalties DROP CONSTRAINT wb_penalties_pkey, ADD PRIMARY KEY (seller_id, kind, penalty_id);
+ALTER TABLE wb_seller_rating DROP CONSTRAINT wb_seller_rating_pkey, ADD PRIMARY KEY (seller_id, date);
+ALTER TABLE wb_promotions DROP CONSTRAINT wb_promotions_pkey, ADD PRIMARY KEY (seller_id, promotion_id);
+ALTER TABLE wb_promotion_uploads DROP CONSTRAINT wb_promotion_uploads_pkey, ADD PRIMARY KEY (seller_id, upload_id);
+ALTER TABLE wb_delivery_orders DROP CONSTRAINT wb_delivery_orders_pkey, ADD PRIMARY KEY (seller_id, order_id);
+
+ALTER TABLE wb_content_skus ADD FOREIGN KEY (seller_id, nm_id)
+    REFERENCES wb_content_cards (seller_id, nm_id) ON DELETE CASCADE;
+ALTER TABLE wb_marketplace_stocks ADD FOREIGN KEY (seller_id, nm_id)
+    REFERENCES wb_content_cards (seller_id, nm_id) ON DELETE CASCADE;
+ALTER TABLE wb_stocks ADD FOREIGN KEY (seller_id, nm_id)
+    REFERENCES wb_content_cards (seller_id, nm_id) ON DELETE CASCADE;
+ALTER TABLE wb_supply_orders ADD FOREIGN KEY (seller_id, supply_id)
+    REFERENCES wb_supplies (seller_id, supply_id) ON DELETE CASCADE;
+ALTER TABLE wb_advert_nms ADD FOREIGN KEY (seller_id, advert_id)
+    REFERENCES wb_adverts (seller_id, advert_id) ON DELETE CASCADE;
+ALTER TABLE wb_chat_attachments ADD FOREIGN KEY (seller_id, event_id)
+    REFERENCES wb_chat_messages (seller_id, event_id) ON DELETE CASCADE;
+ALTER TABLE wb_promotion_uploads ADD FOREIGN KEY (seller_id, promotion_id)
+    REFERENCES wb_promotions (seller_id, promotion_id) ON DELETE CASCADE;
+ALTER TABLE wb_delivery_order_statuses ADD FOREIGN KEY (seller_id, order_id)
+    REFERENCES wb_delivery_orders (seller_id, order_id) ON DELETE CASCADE;
+
+DROP VIEW IF EXISTS wb_paid_storage_daily;
+CREATE VIEW wb_paid_storage_daily AS
+    SELECT seller_id, date, nm_id, sum(warehouse_price) as warehouse_price, sum(barcodes_count) as barcodes_count
+    FROM wb_paid_storage
+    GROUP BY seller_id, date, nm_id;
+
+DROP VIEW IF EXISTS wb_feedbacks_unanswered;
+CREATE VIEW wb_feedbacks_unanswered AS
+    SELECT seller_id, 'feedback' AS kind, feedback_id AS id, nm_id, supplier_article, product_name, product_valuation,
+        text, created_date, (now() AT TIME ZONE 'UTC') - created_date AS waiting
+    FROM wb_feedbacks WHERE NOT answered
+    UNION ALL
+    SELECT seller_id, 'question' AS kind, question_id AS id, nm_id, supplier_article, product_name,
+        NULL AS product_valuation, text, created_date, (now() AT TIME ZONE 'UTC') - created_date AS waiting
+    FROM wb_questions WHERE NOT answered;
+
+DROP VIEW IF EXISTS wb_content_cards_commission;
+CREATE VIEW wb_content_cards_commission AS
+    SELECT c.seller_id, c.nm_id, c.vendor_code, c.subject_id, c.subject_name, m.date, m.kgvp_marketplace,
+        m.kgvp_supplier, m.kgvp_supplier_express, m.paid_storage_kgvp
+    FROM wb_content_cards c
+    JOIN wb_commissions m ON m.seller_id = c.seller_id AND m.subject_id = c.subject_id
+    WHERE m.date = (SELECT max(date) FROM wb_commissions x WHERE x.seller_id = c.seller_id);
+
+DROP VIEW IF EXISTS wb_content_cards_returns;
+CREATE VIEW wb_content_cards_returns AS
+    SELECT c.seller_id, c.nm_id, c.vendor_code,
+        (SELECT count(*) FROM wb_orders o WHERE o.seller_id = c.seller_id AND o.nm_id = c.nm_id AND o.date >= now() - interval '30 days') AS orders_30d,
+        (SELECT count(*) FROM wb_sales s WHERE s.seller_id = c.seller_id AND s.nm_id = c.nm_id AND s.is_return AND s.date >= now() - interval '30 days') AS returns_30d,
+        (SELECT count(*) FROM wb_claims l WHERE l.seller_id = c.seller_id AND l.nm_id = c.nm_id AND l.dt >= now() - interval '30 days') AS claims_30d,
+        (SELECT coalesce(sum(t.in_way_from_client), 0) FROM wb_stocks t WHERE t.seller_id = c.seller_id AND t.nm_id = c.nm_id) AS in_way_from_client
+    FROM wb_content_cards c
+    WHERE NOT c.deleted;
+-- +goose StatementEnd
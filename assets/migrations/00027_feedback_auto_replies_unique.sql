@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose StatementBegin
+-- В журнале остается одна запись на отзыв для отправленных ответов и одна для пробного запуска
+DELETE FROM wb_feedback_auto_replies r USING wb_feedback_auto_replies d
+    WHERE r.seller_id = d.seller_id AND r.feedback_id = d.feedback_id AND r.dry_run = d.dry_run AND r.id > d.id;
+CREATE UNIQUE INDEX IF NOT EXISTS wb_feedback_auto_replies_feedback_idx
+    ON wb_feedback_auto_replies (seller_id, feedback_id, dry_run);
+-- +goose StatementEnd
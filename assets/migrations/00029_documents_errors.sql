@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Документы, которые не удалось загрузить несколько раз подряд, больше не загружаются
+ALTER TABLE wb_documents
+    ADD COLUMN IF NOT EXISTS download_attempts smallint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS download_error text;
+-- +goose StatementEnd
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// syncAdvertsFromAPI синхронизирует рекламные кампании и их карточки с БД
+func syncAdvertsFromAPI(s *seller) ([]*wbapi.Advert, error) {
+	list, err := s.client.GetAdvertList()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении списка рекламных кампаний произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	var advertIDs []uint32
+	for _, item := range list {
+		advertIDs = append(advertIDs, item.AdvertID)
+	}
+
+	adverts, err := s.client.GetAdverts(advertIDs)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении информации о рекламных кампаниях произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d рекламных кампаний", len(adverts)))
+
+	if err := s.db.syncAdverts(adverts); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации рекламных кампаний произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	return adverts, nil
+}
+
+// advertSync синхронизирует рекламные кампании, их бюджеты, баланс счета продвижения
+// и статистику кампаний за последние дни, заданные настройкой advert.stats_days.
+// Статистика запрашивается для активных и приостановленных кампаний, а также для завершенных за этот период
+func advertSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	adverts, err := syncAdvertsFromAPI(s)
+	if err != nil {
+		return
+	}
+
+	begin := time.Now().AddDate(0, 0, -config.GetInt("advert.stats_days")).Format("2006-01-02")
+	end := time.Now().Format("2006-01-02")
+
+	var advertIDs []uint32
+	for _, a := range adverts {
+		if slices.Contains([]int8{wbapi.AdvertStatusActive, wbapi.AdvertStatusPaused}, a.Status) {
+			budget, err := s.client.GetAdvertBudget(a.AdvertID)
+			if err != nil {
+				slog.Error(fmt.Sprintf("При получении бюджета кампании %d произошла ошибка %s", a.AdvertID, err.Error()))
+			} else if err := s.db.updateAdvertBudget(a.AdvertID, budget); err != nil {
+				return
+			}
+
+			advertIDs = append(advertIDs, a.AdvertID)
+		} else if a.Status == wbapi.AdvertStatusFinished && a.ChangeTime >= begin {
+			advertIDs = append(advertIDs, a.AdvertID)
+		}
+	}
+
+	balance, err := s.client.GetAdvertBalance()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении баланса счета продвижения произошла ошибка %s", err.Error()))
+	} else if err := s.db.upsertAdvertBalance(balance); err != nil {
+		return
+	}
+
+	stats, err := s.client.GetAdvertStats(advertIDs, begin, end)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении статистики рекламных кампаний произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получена статистика %d рекламных кампаний с %s по %s", len(stats), begin, end))
+
+	if err := s.db.syncAdvertStats(stats); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации статистики рекламных кампаний произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// advertStockControl приостанавливает активные кампании, у карточек которых нет остатков
+// на складах WB и складах продавца, и запускает их снова, когда остатки появляются.
+// Запускаются только кампании, приостановленные этой задачей
+func advertStockControl(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if _, err := syncAdvertsFromAPI(s); err != nil {
+		return
+	}
+
+	maxAge := time.Duration(config.GetInt("advert.stock_max_age_hours")) * time.Hour
+	adverts, err := s.db.getAdvertsStock(time.Now().Add(-maxAge))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении остатков карточек рекламных кампаний из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	for _, a := range adverts {
+		switch {
+		case a.status == wbapi.AdvertStatusActive && a.stock <= 0 && !a.fresh:
+			slog.Warn(fmt.Sprintf("Кампания %d не приостановлена, для ее карточек нет свежих остатков", a.advertID))
+		case a.status == wbapi.AdvertStatusActive && a.stock <= 0:
+			if err := s.client.PauseAdvert(a.advertID); err != nil {
+				slog.Error(fmt.Sprintf("При приостановке кампании %d произошла ошибка %s", a.advertID, err.Error()))
+				continue
+			}
+			if err := s.db.setAdvertPausedByStock(a.advertID, wbapi.AdvertStatusPaused, true); err != nil {
+				continue
+			}
+			slog.Info(fmt.Sprintf("Кампания %d приостановлена, остатки карточек закончились", a.advertID))
+		case a.status == wbapi.AdvertStatusPaused && a.pausedByStock && a.stock > 0:
+			if err := s.client.StartAdvert(a.advertID); err != nil {
+				slog.Error(fmt.Sprintf("При запуске кампании %d произошла ошибка %s", a.advertID, err.Error()))
+				continue
+			}
+			if err := s.db.setAdvertPausedByStock(a.advertID, wbapi.AdvertStatusActive, false); err != nil {
+				continue
+			}
+			slog.Info(fmt.Sprintf("Кампания %d запущена, остаток карточек %d", a.advertID, a.stock))
+		}
+	}
+}
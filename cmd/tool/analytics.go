@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// paidReportsSync загружает отчеты о платном хранении и платной приемке за последние дни.
+// Количество дней задается настройкой analytics.paid_reports_days, отчет о хранении
+// формируется не более чем за 8 дней
+func paidReportsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	days := config.GetInt("analytics.paid_reports_days")
+	dateTo := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	dateFrom := time.Now().AddDate(0, 0, -days).Format("2006-01-02")
+
+	storage, err := s.client.GetPaidStorage(dateFrom, dateTo)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении отчета о платном хранении произошла ошибка %s", err.Error()))
+	} else {
+		slog.Info(fmt.Sprintf("Получено %d строк платного хранения с %s по %s", len(storage), dateFrom, dateTo))
+		if err := s.db.replacePaidStorage(dateFrom, dateTo, storage); err != nil {
+			slog.Error(fmt.Sprintf("При синхронизации платного хранения произошла ошибка %s", err.Error()))
+		}
+	}
+
+	acceptance, err := s.client.GetPaidAcceptance(dateFrom, dateTo)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении отчета о платной приемке произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получено %d строк платной приемки с %s по %s", len(acceptance), dateFrom, dateTo))
+
+	if err := s.db.replacePaidAcceptance(dateFrom, dateTo, acceptance); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации платной приемки произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// nmReportSync загружает воронку продаж по карточкам по дням за последние дни.
+// Количество дней задается настройкой analytics.nm_report_days. Чтобы не превышать лимиты api,
+// история по дням запрашивается только для карточек, у которых за период были просмотры
+func nmReportSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	days := config.GetInt("analytics.nm_report_days")
+	begin := time.Now().AddDate(0, 0, -days)
+	end := time.Now().AddDate(0, 0, -1)
+
+	cards, err := s.client.GetNmReportDetail(begin.Format("2006-01-02 00:00:00"), end.Format("2006-01-02 23:59:59"))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении воронки продаж по карточкам произошла ошибка %s", err.Error()))
+		return
+	}
+
+	var nmIDs []uint32
+	for _, card := range cards {
+		if card.Statistics.SelectedPeriod.OpenCardCount > 0 {
+			nmIDs = append(nmIDs, card.NmID)
+		}
+	}
+	slog.Info(fmt.Sprintf("Получено %d карточек в воронке продаж, из них с просмотрами %d", len(cards), len(nmIDs)))
+
+	history, err := s.client.GetNmReportHistory(nmIDs, begin.Format("2006-01-02"), end.Format("2006-01-02"))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении воронки продаж по дням произошла ошибка %s", err.Error()))
+		return
+	}
+
+	if err := s.db.syncNmReportHistory(history); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации воронки продаж произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// searchTextsSync загружает поисковые запросы по карточкам за последнюю закрытую неделю
+func searchTextsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	nmIDs, err := s.db.getNmIDsConentCardsTable()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении карточек из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	from, to := lastClosedWeek(time.Now())
+	week := from.Format("2006-01-02")
+
+	texts, err := s.client.GetSearchTexts(nmIDs, week, to.Format("2006-01-02"), config.GetUint32("analytics.search_texts_limit"))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении поисковых запросов произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получено %d поисковых запросов для %d карточек", len(texts), len(nmIDs)))
+
+	if err := s.db.syncSearchTexts(week, texts); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации поисковых запросов произошла ошибка %s", err.Error()))
+		return
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// stringsFlag флаг, который можно указать несколько раз
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// syncChatsFromAPI синхронизирует чаты и новые события чатов с БД.
+// Если задана настройка chat.attachments_dir, то вложения сохраняются в <каталог>/<событие>/<файл>
+func syncChatsFromAPI(s *seller) ([]*wbapi.Chat, error) {
+	chats, err := s.client.GetChats()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении чатов с покупателями произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d чатов с покупателями", len(chats)))
+
+	if err := s.db.syncChats(chats); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации чатов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	next, err := s.db.getChatEventsCursor()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении курсора событий чатов из БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	events, _, err := s.client.GetChatEvents(next)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении событий чатов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d событий чатов", len(events)))
+
+	if err := s.db.addChatEvents(events); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации событий чатов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	if dir := config.GetString("chat.attachments_dir"); dir != "" {
+		if err := downloadChatAttachments(s, s.dir(dir)); err != nil {
+			slog.Error(fmt.Sprintf("При сохранении вложений чатов произошла ошибка %s", err.Error()))
+			return nil, err
+		}
+	}
+
+	return chats, nil
+}
+
+// downloadMaxAttempts количество попыток загрузки файла, после которого файл больше не загружается
+const downloadMaxAttempts int = 3
+
+// downloadChatAttachments сохраняет в каталог dir вложения, которые еще не сохранены локально.
+// Ошибка загрузки вложения записывается в БД, остальные вложения продолжают загружаться
+func downloadChatAttachments(s *seller, dir string) error {
+	attachments, err := s.db.getChatAttachmentsToDownload(downloadMaxAttempts)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range attachments {
+		name := filepath.Base(a.name)
+		if a.name == "" || name == "." || name == string(filepath.Separator) {
+			name = filepath.Base(a.downloadID)
+		}
+		path := filepath.Join(dir, filepath.Base(a.eventID), name)
+
+		file, err := s.client.DownloadChatFile(a.downloadID)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При загрузке вложения %s произошла ошибка %s", a.downloadID, err.Error()))
+			if err := s.db.setChatAttachmentError(a, err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file, 0644); err != nil {
+			return err
+		}
+
+		if err := s.db.setChatAttachmentPath(a, path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// chatsSync синхронизирует чаты с покупателями
+func chatsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if _, err := syncChatsFromAPI(s); err != nil {
+		return
+	}
+}
+
+// chatsCommand синхронизирует и выводит список чатов с покупателями
+func chatsCommand(s *seller, args []string) error {
+	chats, err := syncChatsFromAPI(s)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range chats {
+		var nmID uint32
+		if c.GoodCard != nil {
+			nmID = c.GoodCard.NmID
+		}
+		var text string
+		if c.LastMessage != nil {
+			text = c.LastMessage.Text
+		}
+		fmt.Printf("%s\t%s\t%d\t%s\n", c.ChatID, c.ClientName, nmID, shortText(text, 60))
+	}
+
+	return nil
+}
+
+// chatSendCommand отправляет сообщение в чат с покупателем
+func chatSendCommand(s *seller, args []string) error {
+	var files stringsFlag
+
+	flags := flag.NewFlagSet("chat-send", flag.ContinueOnError)
+	flags.Var(&files, "file", "Файл для отправки. Можно указать несколько раз")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() < 1 {
+		return errors.New("не указан идентификатор чата")
+	}
+	chatID := flags.Arg(0)
+	text := strings.TrimSpace(strings.Join(flags.Args()[1:], " "))
+	if text == "" && len(files) == 0 {
+		return errors.New("не указан текст сообщения или файл")
+	}
+
+	replySign, err := s.db.getChatReplySign(chatID)
+	if err != nil {
+		return fmt.Errorf("чат %s не найден в БД, выполните команду chats: %w", chatID, err)
+	}
+
+	if err := s.client.SendChatMessage(replySign, text, files); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Сообщение в чат %s отправлено", chatID))
+
+	return nil
+}
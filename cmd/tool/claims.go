@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// syncClaimsFromAPI синхронизирует нерассмотренные и архивные заявки на возврат с БД
+// и возвращает нерассмотренные заявки
+func syncClaimsFromAPI(s *seller) ([]*wbapi.Claim, error) {
+	var active []*wbapi.Claim
+
+	for _, isArchive := range []bool{false, true} {
+		claims, err := s.client.GetClaims(isArchive)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При получении заявок на возврат произошла ошибка %s", err.Error()))
+			return nil, err
+		}
+		slog.Info(fmt.Sprintf("Получено %d заявок на возврат, архивные: %t", len(claims), isArchive))
+
+		if err := s.db.syncClaims(claims, isArchive); err != nil {
+			slog.Error(fmt.Sprintf("При синхронизации заявок на возврат произошла ошибка %s", err.Error()))
+			return nil, err
+		}
+
+		if !isArchive {
+			active = claims
+		}
+	}
+
+	return active, nil
+}
+
+// claimsSync синхронизирует заявки покупателей на возврат
+func claimsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if _, err := syncClaimsFromAPI(s); err != nil {
+		return
+	}
+}
+
+// claimsCommand синхронизирует заявки на возврат и выводит нерассмотренные с доступными действиями
+func claimsCommand(s *seller, args []string) error {
+	claims, err := syncClaimsFromAPI(s)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range claims {
+		fmt.Printf(
+			"%s\t%s\t%d\t%s\t%s\t%s\n",
+			c.ID, c.Dt, c.NmID, c.ImtName, strings.Join(c.Actions, ","), shortText(c.UserComment, 60),
+		)
+	}
+
+	return nil
+}
+
+// claimAnswerCommand отвечает на заявку на возврат
+func claimAnswerCommand(s *seller, args []string) error {
+	if len(args) < 2 {
+		return errors.New("не указан идентификатор заявки или действие")
+	}
+
+	id, action := args[0], args[1]
+	comment := strings.TrimSpace(strings.Join(args[2:], " "))
+
+	if err := s.client.AnswerClaim(id, action, comment); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Ответ %s на заявку %s отправлен", action, id))
+
+	return s.db.markClaimAnswered(id, action, comment)
+}
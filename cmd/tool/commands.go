@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command описывает команду, запускаемую из командной строки
+type command struct {
+	usage       string
+	description string
+	run         func(s *seller, args []string) error
+}
+
+// commands список доступных команд
+var commands = map[string]*command{
+	"supplies": {
+		usage:       "supplies",
+		description: "Синхронизировать и вывести список поставок",
+		run:         suppliesCommand,
+	},
+	"supply-create": {
+		usage:       "supply-create",
+		description: "Собрать новые сборочные задания в поставку",
+		run:         supplyCreateCommand,
+	},
+	"supply-deliver": {
+		usage:       "supply-deliver [-force] <supply_id>",
+		description: "Проверить метаданные заданий, закрыть поставку и передать ее в доставку",
+		run:         supplyDeliverCommand,
+	},
+	"supply-barcode": {
+		usage:       "supply-barcode [-type svg|zplv|zplh|png] [-out file] <supply_id>",
+		description: "Сохранить QR-код поставки в файл",
+		run:         supplyBarcodeCommand,
+	},
+	"supply-stickers": {
+		usage:       "supply-stickers [-type svg|zplv|zplh|png] [-size 58x40|40x30] [-out file|-] <supply_id>",
+		description: "Сохранить стикеры поставки в архив и выгрузить их в PDF или ZPL",
+		run:         supplyStickersCommand,
+	},
+	"supply-cancel": {
+		usage:       "supply-cancel <supply_id>",
+		description: "Удалить пустую поставку",
+		run:         supplyCancelCommand,
+	},
+	"order-cancel": {
+		usage:       "order-cancel <order_id>",
+		description: "Отменить сборочное задание",
+		run:         orderCancelCommand,
+	},
+	"order-meta": {
+		usage:       "order-meta <order_id>",
+		description: "Вывести метаданные сборочного задания",
+		run:         orderMetaCommand,
+	},
+	"order-meta-set": {
+		usage:       "order-meta-set [-sgtin code,code] [-uin uin] [-imei imei] [-gtin gtin] <order_id>",
+		description: "Закрепить КИЗ, УИН, IMEI или GTIN за сборочным заданием",
+		run:         orderMetaSetCommand,
+	},
+	"order-meta-delete": {
+		usage:       "order-meta-delete -key sgtin|uin|imei|gtin <order_id>",
+		description: "Удалить метаданные сборочного задания",
+		run:         orderMetaDeleteCommand,
+	},
+	"offices": {
+		usage:       "offices",
+		description: "Синхронизировать и вывести список складов WB",
+		run:         officesCommand,
+	},
+	"warehouses": {
+		usage:       "warehouses",
+		description: "Синхронизировать и вывести список складов продавца",
+		run:         warehousesCommand,
+	},
+	"warehouses-apply": {
+		usage:       "warehouses-apply [-prune] [-dry-run]",
+		description: "Создать и изменить склады продавца по настройке marketplace.warehouses",
+		run:         warehousesApplyCommand,
+	},
+	"warehouse-delete": {
+		usage:       "warehouse-delete <warehouse_id>",
+		description: "Удалить склад продавца",
+		run:         warehouseDeleteCommand,
+	},
+	"realization-report": {
+		usage:       "realization-report [-from YYYY-MM-DD] [-to YYYY-MM-DD]",
+		description: "Загрузить отчеты о реализации за период. По умолчанию за прошлую неделю",
+		run:         realizationReportCommand,
+	},
+	"feedbacks": {
+		usage:       "feedbacks",
+		description: "Синхронизировать отзывы и вопросы и вывести необработанные",
+		run:         feedbacksCommand,
+	},
+	"feedbacks-count": {
+		usage:       "feedbacks-count",
+		description: "Вывести количество необработанных отзывов и вопросов и среднюю оценку",
+		run:         feedbacksCountCommand,
+	},
+	"feedback-answer": {
+		usage:       "feedback-answer [-edit] <feedback_id> <text>",
+		description: "Ответить на отзыв или изменить ответ",
+		run:         feedbackAnswerCommand,
+	},
+	"feedback-viewed": {
+		usage:       "feedback-viewed <feedback_id>...",
+		description: "Отметить отзывы просмотренными",
+		run:         feedbackViewedCommand,
+	},
+	"question-answer": {
+		usage:       "question-answer <question_id> <text>",
+		description: "Ответить на вопрос или изменить ответ",
+		run:         questionAnswerCommand,
+	},
+	"question-viewed": {
+		usage:       "question-viewed <question_id>...",
+		description: "Отметить вопросы просмотренными",
+		run:         questionViewedCommand,
+	},
+	"feedbacks-auto-reply": {
+		usage:       "feedbacks-auto-reply [-dry-run]",
+		description: "Ответить на отзывы по правилам feedbacks.auto_reply_rules",
+		run:         feedbacksAutoReplyCommand,
+	},
+	"fbw-warehouses": {
+		usage:       "fbw-warehouses",
+		description: "Вывести список складов WB для поставок FBW",
+		run:         fbwWarehousesCommand,
+	},
+	"acceptance-plan": {
+		usage:       "acceptance-plan [-max coefficient] [-file file] [<barcode>=<quantity>...]",
+		description: "Вывести склады WB, принимающие товары, и коэффициенты приемки",
+		run:         acceptancePlanCommand,
+	},
+	"claims": {
+		usage:       "claims",
+		description: "Синхронизировать заявки на возврат и вывести нерассмотренные",
+		run:         claimsCommand,
+	},
+	"claim-answer": {
+		usage:       "claim-answer <claim_id> <action> [comment]",
+		description: "Ответить на заявку на возврат одним из доступных действий",
+		run:         claimAnswerCommand,
+	},
+	"chats": {
+		usage:       "chats",
+		description: "Синхронизировать и вывести список чатов с покупателями",
+		run:         chatsCommand,
+	},
+	"chat-send": {
+		usage:       "chat-send [-file file]... <chat_id> [text]",
+		description: "Отправить сообщение и файлы в чат с покупателем",
+		run:         chatSendCommand,
+	},
+	"documents": {
+		usage:       "documents [-from YYYY-MM-DD] [-to YYYY-MM-DD]",
+		description: "Сохранить в архив и вывести документы за период",
+		run:         documentsCommand,
+	},
+	"document-categories": {
+		usage:       "document-categories",
+		description: "Вывести категории документов",
+		run:         documentCategoriesCommand,
+	},
+	"document-download": {
+		usage:       "document-download [-ext pdf] [-out file] <service_name>",
+		description: "Сохранить документ в файл",
+		run:         documentDownloadCommand,
+	},
+	"balance": {
+		usage:       "balance",
+		description: "Вывести и сохранить баланс продавца",
+		run:         balanceCommand,
+	},
+	"promotions": {
+		usage:       "promotions",
+		description: "Синхронизировать и вывести акции на ближайшие дни",
+		run:         promotionsCommand,
+	},
+	"promotion-enroll": {
+		usage:       "promotion-enroll [-dry-run] [-min-margin N] [-min-stock N] <promotion_id>",
+		description: "Добавить в акцию карточки с маржой и остатком не ниже заданных",
+		run:         promotionEnrollCommand,
+	},
+	"card-cost-price": {
+		usage:       "card-cost-price <nm_id> <cost_price>",
+		description: "Указать себестоимость карточки для расчета маржи",
+		run:         cardCostPriceCommand,
+	},
+	"dbs-orders": {
+		usage:       "dbs-orders",
+		description: "Синхронизировать и вывести незавершенные сборочные задания DBS",
+		run:         dbsOrdersCommand,
+	},
+	"dbs-order": {
+		usage:       "dbs-order confirm|deliver|receive|reject|cancel|client <order_id> [code]",
+		description: "Изменить статус сборочного задания DBS или вывести покупателя",
+		run:         dbsOrderCommand,
+	},
+	"express-orders": {
+		usage:       "express-orders",
+		description: "Синхронизировать и вывести незавершенные задания самовывоза",
+		run:         expressOrdersCommand,
+	},
+	"express-order": {
+		usage:       "express-order confirm|prepare|receive|reject|cancel|client <order_id> [code]",
+		description: "Изменить статус задания самовывоза или вывести покупателя",
+		run:         expressOrderCommand,
+	},
+}
+
+// runCommand запускает команду с указанным именем
+func runCommand(s *seller, name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		printUsage()
+		return fmt.Errorf("неизвестная команда %s", name)
+	}
+
+	return cmd.run(s, args)
+}
+
+// printUsage выводит список доступных команд
+func printUsage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Использование: %s [команда] [аргументы]\n", os.Args[0])
+	fmt.Fprintln(os.Stderr, "Без команды приложение запускает планировщик задач.")
+	fmt.Fprintln(os.Stderr, "Если кабинетов несколько, то команда выполняется для кабинета из настройки WB_SELLER.")
+	fmt.Fprintln(os.Stderr, "Команды:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s\n    \t%s\n", commands[name].usage, commands[name].description)
+	}
+}
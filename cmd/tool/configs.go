@@ -9,11 +9,22 @@ import (
 
 var config = viper.NewWithOptions(viper.EnvKeyReplacer(strings.NewReplacer(".", "_")))
 
-func setConfig() {
+// setConfig задает настройки приложения.
+// Настройки читаются из переменных среды и необязательного файла WB_CONFIG_FILE,
+// переменные среды имеют приоритет над файлом
+func setConfig() error {
 	// Настройки переменных среды
 	config.SetEnvPrefix("WB")
 	config.AutomaticEnv()
 
+	// Файл настроек
+	if configFile := config.GetString("config_file"); configFile != "" {
+		config.SetConfigFile(configFile)
+		if err := config.ReadInConfig(); err != nil {
+			return err
+		}
+	}
+
 	// Настройки базы данных
 	config.SetDefault("database.name", "wb_tool")
 	config.SetDefault("database.host", "localhost")
@@ -36,8 +47,78 @@ func setConfig() {
 	config.SetDefault("cron.stoks_sync_start_immediately", "false")
 	config.SetDefault("cron.checking_time_spent_in_trash", "20 2 * * *")
 	config.SetDefault("cron.checking_time_spent_in_trash_start_immediately", "false")
+	config.SetDefault("cron.marketplace_supply_create", "")
+	config.SetDefault("cron.marketplace_supply_create_start_immediately", "false")
+	config.SetDefault("cron.marketplace_offices_sync", "30 3 * * *")
+	config.SetDefault("cron.marketplace_offices_sync_start_immediately", "false")
+	config.SetDefault("cron.statistics_orders_sync", "30 * * * *")
+	config.SetDefault("cron.statistics_orders_sync_start_immediately", "false")
+	config.SetDefault("cron.statistics_sales_sync", "35 * * * *")
+	config.SetDefault("cron.statistics_sales_sync_start_immediately", "false")
+	config.SetDefault("cron.statistics_incomes_sync", "40 */2 * * *")
+	config.SetDefault("cron.statistics_incomes_sync_start_immediately", "false")
+	config.SetDefault("cron.statistics_report_sync", "0 6 * * 2")
+	config.SetDefault("cron.statistics_report_sync_start_immediately", "false")
+	config.SetDefault("cron.analytics_paid_reports_sync", "")
+	config.SetDefault("cron.analytics_paid_reports_sync_start_immediately", "false")
+	config.SetDefault("cron.analytics_nm_report_sync", "")
+	config.SetDefault("cron.analytics_nm_report_sync_start_immediately", "false")
+	config.SetDefault("cron.analytics_search_texts_sync", "")
+	config.SetDefault("cron.analytics_search_texts_sync_start_immediately", "false")
+	config.SetDefault("cron.feedbacks_sync", "")
+	config.SetDefault("cron.feedbacks_sync_start_immediately", "false")
+	config.SetDefault("cron.feedbacks_auto_reply", "")
+	config.SetDefault("cron.feedbacks_auto_reply_start_immediately", "false")
+	config.SetDefault("cron.advert_sync", "")
+	config.SetDefault("cron.advert_sync_start_immediately", "false")
+	config.SetDefault("cron.advert_stock_control", "")
+	config.SetDefault("cron.advert_stock_control_start_immediately", "false")
+	config.SetDefault("cron.tariffs_sync", "")
+	config.SetDefault("cron.tariffs_sync_start_immediately", "false")
+	config.SetDefault("cron.supplies_acceptance_watch", "")
+	config.SetDefault("cron.supplies_acceptance_watch_start_immediately", "false")
+	config.SetDefault("cron.claims_sync", "")
+	config.SetDefault("cron.claims_sync_start_immediately", "false")
+	config.SetDefault("cron.chats_sync", "")
+	config.SetDefault("cron.chats_sync_start_immediately", "false")
+	config.SetDefault("cron.documents_sync", "")
+	config.SetDefault("cron.documents_sync_start_immediately", "false")
+	config.SetDefault("cron.finance_balance_sync", "")
+	config.SetDefault("cron.finance_balance_sync_start_immediately", "false")
+	config.SetDefault("cron.penalties_sync", "")
+	config.SetDefault("cron.penalties_sync_start_immediately", "false")
+	config.SetDefault("cron.promotions_sync", "")
+	config.SetDefault("cron.promotions_sync_start_immediately", "false")
+	config.SetDefault("cron.marketplace_delivery_sync", "")
+	config.SetDefault("cron.marketplace_delivery_sync_start_immediately", "false")
 
 	// Общие настройки
 	config.SetDefault("max_days_in_trash", 25)
 	config.SetDefault("statistics.date_from", "2023-11-01")
+	config.SetDefault("marketplace.stickers_dir", "stickers")
+	config.SetDefault("marketplace.stickers_type", "png")
+	config.SetDefault("marketplace.stickers_size", "58x40")
+	config.SetDefault("marketplace.stickers_archive", "false")
+	config.SetDefault("analytics.paid_reports_days", 8)
+	config.SetDefault("analytics.nm_report_days", 7)
+	config.SetDefault("analytics.search_texts_limit", 30)
+	config.SetDefault("feedbacks.sync_days", 30)
+	config.SetDefault("feedbacks.auto_reply_daily_limit", 100)
+	config.SetDefault("feedbacks.auto_reply_dry_run", "false")
+	config.SetDefault("advert.stats_days", 3)
+	config.SetDefault("advert.stock_max_age_hours", 6)
+	config.SetDefault("chat.attachments_dir", "")
+	config.SetDefault("documents.dir", "documents")
+	config.SetDefault("documents.extensions", "pdf")
+	config.SetDefault("documents.sync_days", 30)
+	config.SetDefault("penalties.days", 7)
+	config.SetDefault("promotions.days_ahead", 30)
+	config.SetDefault("promotions.notify_days", 3)
+	config.SetDefault("promotions.min_margin", 20)
+	config.SetDefault("promotions.min_stock", 1)
+	config.SetDefault("notify.telegram_token", "")
+	config.SetDefault("notify.telegram_chat_id", "")
+	config.SetDefault("notify.webhook_url", "")
+
+	return nil
 }
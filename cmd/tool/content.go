@@ -68,11 +68,11 @@ func newCards(wbcs *wbapi.ContentCards, trashed bool) *contentCards {
 }
 
 // contentSync синхронизирует карточки с БД
-func contentSync(wbClient *wbapi.Client, job gocron.Job) {
+func contentSync(s *seller, job gocron.Job) {
 	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
 
 	// Синнхронизация корзины
-	wbCards, err := wbClient.GetCardsTrash()
+	wbCards, err := s.client.GetCardsTrash()
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении карточек произошла ошибка %s", err.Error()))
 		return
@@ -80,13 +80,13 @@ func contentSync(wbClient *wbapi.Client, job gocron.Job) {
 	trashedCards := newCards(wbCards, true)
 	slog.Info(fmt.Sprintf("Получено %d карточек корзины", trashedCards.count()))
 
-	if err := pdb.syncContentCards(trashedCards); err != nil {
+	if err := s.db.syncContentCards(trashedCards); err != nil {
 		slog.Error(fmt.Sprintf("При сохранении карточек в БД произошла ошибка %s", err.Error()))
 		return
 	}
 
 	// Синхронизация карточек
-	wbCards, err = wbClient.GetCards()
+	wbCards, err = s.client.GetCards()
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении карточек произошла ошибка %s", err.Error()))
 		return
@@ -94,23 +94,23 @@ func contentSync(wbClient *wbapi.Client, job gocron.Job) {
 	cards := newCards(wbCards, false)
 	slog.Info(fmt.Sprintf("Получено %d карточек", cards.count()))
 
-	if err := pdb.syncContentCards(cards); err != nil {
+	if err := s.db.syncContentCards(cards); err != nil {
 		slog.Error(fmt.Sprintf("При сохранении карточек в БД произошла ошибка %s", err.Error()))
 		return
 	}
 }
 
 // getNmIDsForDelete получает список nmID для удаления
-func (cs *contentCards) getNmIDsForDelete() ([]uint32, error) {
+func (cs *contentCards) getNmIDsForDelete(p *pClinet) ([]uint32, error) {
 	var ids []uint32
 	var err error
 	var res []uint32
 
 	if cs.trashed {
-		ids, err = pdb.getTrashedNmIDsConentCardsTable()
+		ids, err = p.getTrashedNmIDsConentCardsTable()
 		slog.Info(fmt.Sprintf("Получено %d карточек карзины из БД", len(ids)))
 	} else {
-		ids, err = pdb.getNmIDsConentCardsTable()
+		ids, err = p.getNmIDsConentCardsTable()
 		slog.Info(fmt.Sprintf("Получено %d карточек из БД", len(ids)))
 	}
 
@@ -134,13 +134,13 @@ func (cs *contentCards) getNmIDsForDelete() ([]uint32, error) {
 
 // checkingTimeSpentInTrash проверяет как долго карточки хранятся в коризне.
 // Если карточка хранится слишком долго, то автоматически восстанавливается и обратно помещяется в корзину.
-func checkingTimeSpentInTrash(wbClient *wbapi.Client, job gocron.Job) {
+func checkingTimeSpentInTrash(s *seller, job gocron.Job) {
 	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
 
 	maxDays := config.GetInt("max_days_in_trash")
 	slog.Info(fmt.Sprintf("Запущен поиск карточек в карзине старше %d дней", maxDays))
 
-	nmIDs, err := pdb.getContentCardsForRecoverToExpire(maxDays)
+	nmIDs, err := s.db.getContentCardsForRecoverToExpire(maxDays)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении карточек в БД для передобавления в корзниу произошла ошибка %s", err.Error()))
 		return
@@ -148,7 +148,7 @@ func checkingTimeSpentInTrash(wbClient *wbapi.Client, job gocron.Job) {
 	slog.Debug(fmt.Sprintf("Найдено %d карточек в БД старше %d дней", len(nmIDs), maxDays))
 
 	for _, nmID := range nmIDs {
-		if err := recoverAndMoveToTrash(wbClient, nmID); err == nil {
+		if err := recoverAndMoveToTrash(s, nmID); err == nil {
 			slog.Info(fmt.Sprintf("Карточка %d передобавлена в корзину", nmID))
 		}
 	}
@@ -156,35 +156,35 @@ func checkingTimeSpentInTrash(wbClient *wbapi.Client, job gocron.Job) {
 }
 
 // recoverAndMoveToTrash востанавливает указанную карточку из корзины и возвращает обратно
-func recoverAndMoveToTrash(wbClient *wbapi.Client, nmID uint32) error {
-	tx, err := pdb.pool.Begin(pdb.ctx)
+func recoverAndMoveToTrash(s *seller, nmID uint32) error {
+	tx, err := s.db.pool.Begin(s.db.ctx)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
 		return err
 	}
 
-	defer tx.Rollback(pdb.ctx)
+	defer tx.Rollback(s.db.ctx)
 
-	if err := pdb.recoverCard(tx, nmID); err != nil {
+	if err := s.db.recoverCard(tx, nmID); err != nil {
 		return err
 	}
 
 	nmIDs := []uint32{nmID}
-	if err := wbClient.RecoverCards(nmIDs); err != nil {
+	if err := s.client.RecoverCards(nmIDs); err != nil {
 		slog.Error(fmt.Sprintf("При востановлении карточки %d возникла ошибка %s", nmID, err.Error()))
 		return err
 	}
 
-	if err := pdb.moveToTrash(tx, nmID); err != nil {
+	if err := s.db.moveToTrash(tx, nmID); err != nil {
 		return err
 	}
 
-	if err := wbClient.MoveToTrash(nmIDs); err != nil {
+	if err := s.client.MoveToTrash(nmIDs); err != nil {
 		slog.Error(fmt.Sprintf("При переносе карточки %d в корзину возникла ошибка %s", nmID, err.Error()))
 		return err
 	}
 
-	if err := tx.Commit(pdb.ctx); err != nil {
+	if err := tx.Commit(s.db.ctx); err != nil {
 		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/e-vasilyev/wb-tool/assets"
@@ -16,8 +17,9 @@ import (
 
 // pClinet описывает подключение к базе данных postgresql
 type pClinet struct {
-	pool *pgxpool.Pool
-	ctx  context.Context
+	pool     *pgxpool.Pool
+	ctx      context.Context
+	sellerID int32
 }
 
 // contentSkusTable описывает структуру таблицы wb_content_skus
@@ -100,7 +102,7 @@ func (p *pClinet) syncContentCards(cs *contentCards) error {
 		}
 	}
 
-	ids, err := cs.getNmIDsForDelete()
+	ids, err := cs.getNmIDsForDelete(p)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении nmID для удаления произошла ошибка %s", err.Error()))
 		return err
@@ -123,14 +125,14 @@ func (p *pClinet) syncContentCards(cs *contentCards) error {
 func (p *pClinet) upsertContentTrashedCard(tx pgx.Tx, card contentCard) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`INSERT INTO wb_content_cards (nm_id, vendor_code, subject_id, subject_name, trashed_at, trashed, deleted, updated_timestamp) 
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-			ON CONFLICT (nm_id) DO UPDATE
+		`INSERT INTO wb_content_cards (nm_id, vendor_code, subject_id, subject_name, trashed_at, trashed, deleted, updated_timestamp, seller_id) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (seller_id, nm_id) DO UPDATE
 				SET vendor_code = $2, subject_id = $3, subject_name = $4, 
 					trashed_at = $5, trashed = $6, deleted = $7, updated_timestamp = $8`,
 		card.nmID, card.vendorCode, card.subjectID,
 		card.subjectName, card.trashedAt, true, false,
-		time.Now().UTC().Format("2006-01-02 03:04:05"),
+		time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При записи карточки %d в базу данных возникла ошибка %s", card.nmID, err.Error()))
@@ -144,14 +146,14 @@ func (p *pClinet) upsertContentTrashedCard(tx pgx.Tx, card contentCard) error {
 func (p *pClinet) upsertContentCard(tx pgx.Tx, card contentCard) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`INSERT INTO wb_content_cards (nm_id, imt_id, vendor_code, subject_id, subject_name, brand, title, trashed, deleted, updated_timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-			ON CONFLICT (nm_id) DO UPDATE
+		`INSERT INTO wb_content_cards (nm_id, imt_id, vendor_code, subject_id, subject_name, brand, title, trashed, deleted, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (seller_id, nm_id) DO UPDATE
 				SET imt_id = $2, vendor_code = $3, subject_id = $4, subject_name = $5, 
 					brand = $6, title = $7, trashed = $8, deleted = $9, updated_timestamp = $10`,
 		card.nmID, card.imtID, card.vendorCode, card.subjectID,
 		card.subjectName, card.brand, card.title, false, false,
-		time.Now().UTC().Format("2006-01-02 03:04:05"),
+		time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При записи карточки %d в базу данных возникла ошибка %s", card.nmID, err.Error()))
@@ -166,10 +168,10 @@ func (p *pClinet) upsetSkus(tx pgx.Tx, card contentCard) error {
 	for _, sku := range card.skus {
 		_, err := tx.Exec(
 			p.ctx,
-			`INSERT INTO wb_content_skus (sku, nm_id)
-				VALUES ($1, $2)
-				ON CONFLICT (sku) DO NOTHING`,
-			sku, card.nmID,
+			`INSERT INTO wb_content_skus (sku, nm_id, seller_id)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (seller_id, sku) DO NOTHING`,
+			sku, card.nmID, p.sellerID,
 		)
 		if err != nil {
 			slog.Error(fmt.Sprintf("При записи баркода %s в базу дунных возникла ошибка %s", sku, err.Error()))
@@ -184,8 +186,8 @@ func (p *pClinet) upsetSkus(tx pgx.Tx, card contentCard) error {
 func (p *pClinet) deleteSku(tx pgx.Tx, sku string) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`DELETE FROM wb_content_skus WHERE sku = $1`,
-		sku,
+		`DELETE FROM wb_content_skus WHERE sku = $1 AND seller_id = $2`,
+		sku, p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При удалении баркода %s возникла ошибка %s", sku, err.Error()))
@@ -199,8 +201,8 @@ func (p *pClinet) deleteSku(tx pgx.Tx, sku string) error {
 func (p *pClinet) deleteMarketplaceStockBySku(tx pgx.Tx, sku string) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`DELETE FROM wb_marketplace_stocks WHERE sku = $1`,
-		sku,
+		`DELETE FROM wb_marketplace_stocks WHERE sku = $1 AND seller_id = $2`,
+		sku, p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При удалении остатка по баркоду %s возникла ошибка %s", sku, err.Error()))
@@ -213,7 +215,8 @@ func (p *pClinet) deleteMarketplaceStockBySku(tx pgx.Tx, sku string) error {
 // getContentSkusTable возвращает содержимое таблицы wb_content_skus из БД
 func (p *pClinet) getContentSkusTable() ([]*contentSkusTable, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT sku, nm_id FROM wb_content_skus NATURAL JOIN wb_content_cards WHERE deleted is false",
+		p.ctx, "SELECT sku, nm_id FROM wb_content_skus NATURAL JOIN wb_content_cards WHERE deleted is false AND seller_id = $1",
+		p.sellerID,
 	)
 	if err != nil {
 		return nil, err
@@ -232,7 +235,8 @@ func (p *pClinet) getContentSkusTable() ([]*contentSkusTable, error) {
 // getTrashedNmIDsConentCardsTable получает массив nmID из таблицы карточек в корзине
 func (p *pClinet) getTrashedNmIDsConentCardsTable() ([]uint32, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT nm_id FROM wb_content_cards WHERE trashed is true and deleted is false",
+		p.ctx, "SELECT nm_id FROM wb_content_cards WHERE trashed is true and deleted is false AND seller_id = $1",
+		p.sellerID,
 	)
 	if err != nil {
 		return []uint32{}, err
@@ -251,7 +255,8 @@ func (p *pClinet) getTrashedNmIDsConentCardsTable() ([]uint32, error) {
 // getNmIDsConentCardsTable получает массив nmID из таблицы карточек
 func (p *pClinet) getNmIDsConentCardsTable() ([]uint32, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT nm_id FROM wb_content_cards WHERE trashed is false and deleted is false",
+		p.ctx, "SELECT nm_id FROM wb_content_cards WHERE trashed is false and deleted is false AND seller_id = $1",
+		p.sellerID,
 	)
 	if err != nil {
 		return []uint32{}, err
@@ -273,8 +278,8 @@ func (p *pClinet) markAsDeleted(tx pgx.Tx, ids []uint32) error {
 		_, err := tx.Exec(
 			p.ctx,
 			`UPDATE wb_content_cards SET deleted = $2, updated_timestamp = $3
-				WHERE nm_id=$1`,
-			id, true, time.Now().UTC().Format("2006-01-02 03:04:05"),
+				WHERE nm_id=$1 AND seller_id = $4`,
+			id, true, time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 		)
 		if err != nil {
 			slog.Error(fmt.Sprintf("При удалении карточки %d возникла ошибка %s", id, err.Error()))
@@ -300,7 +305,7 @@ func (p *pClinet) syncMarketplaceStocks(mps *marketplaceStocks, skusRows []*cont
 
 	for _, row := range skusRows {
 		if _, ok := mps.stocks[row.Sku]; ok {
-			if err := pdb.upsertMarketplaceStocks(tx, row.Sku, row.NmID, mps.getStock(row.Sku)); err != nil {
+			if err := p.upsertMarketplaceStocks(tx, row.Sku, row.NmID, mps.getStock(row.Sku)); err != nil {
 				return err
 			}
 		} else {
@@ -308,7 +313,7 @@ func (p *pClinet) syncMarketplaceStocks(mps *marketplaceStocks, skusRows []*cont
 		}
 	}
 
-	skus, err := mps.getSkusForDelete()
+	skus, err := mps.getSkusForDelete(p)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении списка баркодов для удаления остатков произошла ошибка %s", err.Error()))
 		return err
@@ -333,11 +338,11 @@ func (p *pClinet) syncMarketplaceStocks(mps *marketplaceStocks, skusRows []*cont
 func (p *pClinet) upsertMarketplaceStocks(tx pgx.Tx, sku string, nmID uint32, ammount uint32) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`INSERT INTO wb_marketplace_stocks (sku, nm_id, amount, updated_timestamp)
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (sku) DO UPDATE
+		`INSERT INTO wb_marketplace_stocks (sku, nm_id, amount, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (seller_id, sku) DO UPDATE
 				SET amount = $3, updated_timestamp = $4`,
-		sku, nmID, ammount, time.Now().UTC().Format("2006-01-02 03:04:05"),
+		sku, nmID, ammount, time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При записи остатка карточки %d (баркод %s) в базу данных возникла ошибка %s", nmID, sku, err.Error()))
@@ -350,7 +355,8 @@ func (p *pClinet) upsertMarketplaceStocks(tx pgx.Tx, sku string, nmID uint32, am
 // getSkusMarketplaceStocksTable получает массив sku из таблицы карточек
 func (p *pClinet) getSkusMarketplaceStocksTable() ([]string, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT sku FROM wb_marketplace_stocks",
+		p.ctx, "SELECT sku FROM wb_marketplace_stocks WHERE seller_id = $1",
+		p.sellerID,
 	)
 	if err != nil {
 		return []string{}, err
@@ -378,7 +384,7 @@ func (p *pClinet) syncSupplierStocks(supplierStocks *supplierStocks, skusRows []
 
 	for _, row := range skusRows {
 		if _, ok := supplierStocks.stocks[row.Sku]; ok {
-			if err := pdb.upsertSupplierStocks(tx, row.Sku, row.NmID, supplierStocks.stocks[row.Sku]); err != nil {
+			if err := p.upsertSupplierStocks(tx, row.Sku, row.NmID, supplierStocks.stocks[row.Sku]); err != nil {
 				return err
 			}
 		} else {
@@ -386,7 +392,7 @@ func (p *pClinet) syncSupplierStocks(supplierStocks *supplierStocks, skusRows []
 		}
 	}
 
-	skus, err := supplierStocks.getSkusForDelete()
+	skus, err := supplierStocks.getSkusForDelete(p)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении списка баркодов для удаления остатков произошла ошибка %s", err.Error()))
 		return err
@@ -411,13 +417,13 @@ func (p *pClinet) syncSupplierStocks(supplierStocks *supplierStocks, skusRows []
 func (p *pClinet) upsertSupplierStocks(tx pgx.Tx, sku string, nmID uint32, stock *supplierStock) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`INSERT INTO wb_stocks (sku, nm_id, quantity, quantity_full, in_way_to_client, in_way_from_client, updated_timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
-			ON CONFLICT (sku) DO UPDATE
+		`INSERT INTO wb_stocks (sku, nm_id, quantity, quantity_full, in_way_to_client, in_way_from_client, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (seller_id, sku) DO UPDATE
 				SET quantity = $3, quantity_full=$4, 
 				in_way_to_client=$5, in_way_from_client=$6, updated_timestamp = $7`,
 		sku, nmID, stock.quantity, stock.quantityFull,
-		stock.inWayToClient, stock.inWayFromClient, time.Now().UTC().Format("2006-01-02 03:04:05"),
+		stock.inWayToClient, stock.inWayFromClient, time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При записи остатка карточки %d (баркод %s) в базу данных возникла ошибка %s", nmID, sku, err.Error()))
@@ -430,7 +436,8 @@ func (p *pClinet) upsertSupplierStocks(tx pgx.Tx, sku string, nmID uint32, stock
 // getSkusSupplierStocksTable получает массив sku из таблицы карточек
 func (p *pClinet) getSkusSupplierStocksTable() ([]string, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT sku FROM wb_stocks",
+		p.ctx, "SELECT sku FROM wb_stocks WHERE seller_id = $1",
+		p.sellerID,
 	)
 	if err != nil {
 		return []string{}, err
@@ -450,8 +457,8 @@ func (p *pClinet) getSkusSupplierStocksTable() ([]string, error) {
 func (p *pClinet) deleteSupplierStockBySku(tx pgx.Tx, sku string) error {
 	_, err := tx.Exec(
 		p.ctx,
-		`DELETE FROM wb_stocks WHERE sku = $1`,
-		sku,
+		`DELETE FROM wb_stocks WHERE sku = $1 AND seller_id = $2`,
+		sku, p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При удалении остатка по баркоду %s возникла ошибка %s", sku, err.Error()))
@@ -465,8 +472,10 @@ func (p *pClinet) deleteSupplierStockBySku(tx pgx.Tx, sku string) error {
 // В качестве параметра указывается возвращать с корзины или нет
 func (p *pClinet) getContentCardsTable(trashed bool) ([]*contentCardsTable, error) {
 	rows, err := p.pool.Query(
-		p.ctx, "SELECT * FROM wb_content_cards WHERE deleted is false AND trashed = $1",
-		trashed,
+		p.ctx,
+		`SELECT nm_id, imt_id, vendor_code, subject_id, subject_name, brand, title, trashed_at, trashed, deleted,
+			updated_timestamp FROM wb_content_cards WHERE deleted is false AND trashed = $1 AND seller_id = $2`,
+		trashed, p.sellerID,
 	)
 	if err != nil {
 		return nil, err
@@ -490,16 +499,17 @@ func (p *pClinet) getContentCardsForRecoverToExpire(days int) ([]uint32, error)
 		p.ctx, `
 		SELECT cards.nm_id FROM wb_content_cards as cards JOIN 
 			(SELECT nm_id, sum(amount) as amount FROM (
-			(SELECT nm_id, quantity_full as amount FROM wb_stocks) 
+			(SELECT nm_id, quantity_full as amount FROM wb_stocks WHERE seller_id = $2) 
 			UNION 
-			(SELECT nm_id, amount FROM wb_marketplace_stocks))
+			(SELECT nm_id, amount FROM wb_marketplace_stocks WHERE seller_id = $2))
 			GROUP BY nm_id) as stock ON cards.nm_id = stock.nm_id
 		WHERE (
+			cards.seller_id = $2 AND
 			deleted = false AND 
 			trashed = true AND
 			no_recovery = false AND
 			(current_timestamp - trashed_at) > $1::interval)`,
-		fmt.Sprintf("%d days", days),
+		fmt.Sprintf("%d days", days), p.sellerID,
 	)
 	if err != nil {
 		return []uint32{}, err
@@ -520,8 +530,8 @@ func (p *pClinet) getContentCardsForRecoverToExpire(days int) ([]uint32, error)
 func (p *pClinet) recoverCard(tx pgx.Tx, nmID uint32) error {
 	_, err := tx.Exec(
 		p.ctx,
-		"UPDATE wb_content_cards SET trashed=false, updated_timestamp=$2 WHERE nm_id=$1",
-		nmID, time.Now().UTC().Format("2006-01-02 03:04:05"),
+		"UPDATE wb_content_cards SET trashed=false, updated_timestamp=$2 WHERE nm_id=$1 AND seller_id=$3",
+		nmID, time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При изменении статуса карточки %d в БД возникла ошибка %s", nmID, err.Error()))
@@ -536,8 +546,8 @@ func (p *pClinet) recoverCard(tx pgx.Tx, nmID uint32) error {
 func (p *pClinet) moveToTrash(tx pgx.Tx, nmID uint32) error {
 	_, err := tx.Exec(
 		p.ctx,
-		"UPDATE wb_content_cards SET trashed=true, updated_timestamp=$2 WHERE nm_id=$1",
-		nmID, time.Now().UTC().Format("2006-01-02 03:04:05"),
+		"UPDATE wb_content_cards SET trashed=true, updated_timestamp=$2 WHERE nm_id=$1 AND seller_id=$3",
+		nmID, time.Now().UTC().Format("2006-01-02 03:04:05"), p.sellerID,
 	)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При изменении статуса карточки %d в БД возникла ошибка %s", nmID, err.Error()))
@@ -546,3 +556,23 @@ func (p *pClinet) moveToTrash(tx pgx.Tx, nmID uint32) error {
 
 	return nil
 }
+
+// nullIfEmpty возвращает nil для пустой строки.
+// Используется для записи необязательных дат в БД
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// nullIfZeroDate возвращает nil для пустой даты или даты 0001-01-01,
+// которой api статистики обозначает отсутствие значения
+func nullIfZeroDate(s string) *string {
+	if strings.HasPrefix(s, "0001-01-01") {
+		return nil
+	}
+
+	return nullIfEmpty(s)
+}
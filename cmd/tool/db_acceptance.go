@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// acceptanceSlotKey ключ слота приемки
+type acceptanceSlotKey struct {
+	warehouseID uint32
+	boxTypeID   uint8
+	date        string
+}
+
+// getMatchedAcceptanceSlots возвращает слоты приемки, о которых уже было отправлено уведомление
+func (p *pClinet) getMatchedAcceptanceSlots() (map[acceptanceSlotKey]bool, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT warehouse_id, box_type_id, to_char(date, 'YYYY-MM-DD') FROM wb_acceptance_slots
+			WHERE matched AND seller_id = $1`,
+		p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	slots := make(map[acceptanceSlotKey]bool)
+	for rows.Next() {
+		var key acceptanceSlotKey
+		if err := rows.Scan(&key.warehouseID, &key.boxTypeID, &key.date); err != nil {
+			return nil, err
+		}
+		slots[key] = true
+	}
+
+	return slots, rows.Err()
+}
+
+// upsertAcceptanceSlot записывает в БД состояние слота приемки
+func (p *pClinet) upsertAcceptanceSlot(c *wbapi.AcceptanceCoefficient, matched bool, notified bool) error {
+	now := time.Now().UTC().Format("2006-01-02 15:04:05")
+
+	var notifiedTimestamp *string
+	if notified {
+		notifiedTimestamp = &now
+	}
+
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_acceptance_slots (warehouse_id, box_type_id, date, warehouse_name, box_type_name, coefficient,
+			allow_unload, matched, notified_timestamp, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (seller_id, warehouse_id, box_type_id, date) DO UPDATE SET coefficient = $6, allow_unload = $7,
+			matched = $8, notified_timestamp = coalesce($9, wb_acceptance_slots.notified_timestamp),
+			updated_timestamp = $10`,
+		c.WarehouseID, c.BoxTypeID, c.Date, c.WarehouseName, c.BoxTypeName, c.Coefficient, c.AllowUnload,
+		matched, notifiedTimestamp, now, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи слота приемки склада %d в базу данных возникла ошибка %s", c.WarehouseID, err.Error()))
+	}
+
+	return err
+}
+
+// deleteExpiredAcceptanceSlots удаляет из БД прошедшие слоты приемки
+func (p *pClinet) deleteExpiredAcceptanceSlots() error {
+	_, err := p.pool.Exec(p.ctx, `DELETE FROM wb_acceptance_slots WHERE date < current_date AND seller_id = $1`, p.sellerID)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении прошедших слотов приемки возникла ошибка %s", err.Error()))
+	}
+
+	return err
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// syncAdverts записывает кампании и их карточки полученные с api в БД.
+// Признак приостановки из-за отсутствия остатков сбрасывается, если кампания уже не приостановлена
+func (p *pClinet) syncAdverts(adverts []*wbapi.Advert) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, a := range adverts {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_adverts (advert_id, name, type, status, daily_budget, payment_type, create_time,
+				change_time, start_time, end_time, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $13)
+				ON CONFLICT (seller_id, advert_id) DO UPDATE SET name = $2, type = $3, status = $4, daily_budget = $5,
+				payment_type = $6, create_time = $7, change_time = $8, start_time = $9, end_time = $10,
+				updated_timestamp = $11, paused_by_stock = wb_adverts.paused_by_stock AND $4 = $12`,
+			a.AdvertID, a.Name, a.Type, a.Status, a.DailyBudget, nullIfEmpty(a.PaymentType),
+			nullIfEmpty(a.CreateTime), nullIfEmpty(a.ChangeTime), nullIfEmpty(a.StartTime), nullIfEmpty(a.EndTime),
+			time.Now().UTC().Format("2006-01-02 15:04:05"), wbapi.AdvertStatusPaused, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи кампании %d в базу данных возникла ошибка %s", a.AdvertID, err.Error()))
+			return err
+		}
+
+		if _, err := tx.Exec(p.ctx, `DELETE FROM wb_advert_nms WHERE advert_id = $1 AND seller_id = $2`, a.AdvertID, p.sellerID); err != nil {
+			slog.Error(fmt.Sprintf("При удалении карточек кампании %d возникла ошибка %s", a.AdvertID, err.Error()))
+			return err
+		}
+
+		for _, nmID := range a.NmIDs() {
+			_, err := tx.Exec(
+				p.ctx,
+				`INSERT INTO wb_advert_nms (advert_id, nm_id, seller_id) VALUES ($1, $2, $3)`,
+				a.AdvertID, nmID, p.sellerID,
+			)
+			if err != nil {
+				slog.Error(fmt.Sprintf("При записи карточки %d кампании %d возникла ошибка %s", nmID, a.AdvertID, err.Error()))
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Рекламные кампании успешно синхронизировны"))
+
+	return nil
+}
+
+// updateAdvertBudget записывает в БД бюджет кампании
+func (p *pClinet) updateAdvertBudget(advertID uint32, budget *wbapi.AdvertBudget) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_adverts SET budget = $2, updated_timestamp = $3 WHERE advert_id = $1 AND seller_id = $4`,
+		advertID, budget.Total, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи бюджета кампании %d в базу данных возникла ошибка %s", advertID, err.Error()))
+	}
+
+	return err
+}
+
+// syncAdvertStats записывает статистику кампаний по карточкам и дням в БД.
+// Показатели карточки суммируются по всем платформам
+func (p *pClinet) syncAdvertStats(stats []*wbapi.AdvertStats) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, s := range stats {
+		for _, day := range s.Days {
+			nms := make(map[uint32]*wbapi.AdvertStatsValues)
+			for _, app := range day.Apps {
+				for _, nm := range app.Nm {
+					v, ok := nms[nm.NmID]
+					if !ok {
+						v = &wbapi.AdvertStatsValues{}
+						nms[nm.NmID] = v
+					}
+					v.Views += nm.Views
+					v.Clicks += nm.Clicks
+					v.Sum += nm.Sum
+					v.Atbs += nm.Atbs
+					v.Orders += nm.Orders
+					v.Shks += nm.Shks
+					v.SumPrice += nm.SumPrice
+				}
+			}
+
+			for nmID, v := range nms {
+				_, err := tx.Exec(
+					p.ctx,
+					`INSERT INTO wb_advert_stats (advert_id, nm_id, date, views, clicks, sum, atbs, orders, shks,
+						sum_price, updated_timestamp, seller_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+						ON CONFLICT (seller_id, advert_id, nm_id, date) DO UPDATE SET views = $4, clicks = $5, sum = $6,
+						atbs = $7, orders = $8, shks = $9, sum_price = $10, updated_timestamp = $11`,
+					s.AdvertID, nmID, day.Date, v.Views, v.Clicks, v.Sum, v.Atbs, v.Orders, v.Shks, v.SumPrice,
+					time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+				)
+				if err != nil {
+					slog.Error(fmt.Sprintf("При записи статистики кампании %d в базу данных возникла ошибка %s", s.AdvertID, err.Error()))
+					return err
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Статистика рекламных кампаний успешно синхронизировна"))
+
+	return nil
+}
+
+// upsertAdvertBalance записывает в БД баланс счета продвижения за текущий день
+func (p *pClinet) upsertAdvertBalance(balance *wbapi.AdvertBalance) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_advert_balance (date, balance, net, bonus, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (seller_id, date) DO UPDATE SET balance = $2, net = $3, bonus = $4, updated_timestamp = $5`,
+		time.Now().Format("2006-01-02"), balance.Balance, balance.Net, balance.Bonus,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи баланса счета продвижения в базу данных возникла ошибка %s", err.Error()))
+	}
+
+	return err
+}
+
+// advertStock описывает активную или приостановленную кампанию с суммарным остатком ее карточек.
+// fresh показывает, что для всех карточек кампании есть недавно обновленные остатки
+type advertStock struct {
+	advertID      uint32
+	status        int8
+	pausedByStock bool
+	stock         int64
+	fresh         bool
+}
+
+// getAdvertsStock возвращает активные и приостановленные кампании с суммарным остатком их карточек
+// на складах WB и складах продавца. Остатки считаются свежими, если обновлены после updatedFrom
+func (p *pClinet) getAdvertsStock(updatedFrom time.Time) ([]*advertStock, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT a.advert_id, a.status, a.paused_by_stock,
+			coalesce(sum((SELECT coalesce(sum(s.quantity), 0) FROM wb_stocks s
+				WHERE s.nm_id = n.nm_id AND s.seller_id = a.seller_id) +
+				(SELECT coalesce(sum(m.amount), 0) FROM wb_marketplace_stocks m
+				WHERE m.nm_id = n.nm_id AND m.seller_id = a.seller_id)), 0),
+			coalesce(bool_and(
+				EXISTS (SELECT 1 FROM wb_stocks s
+					WHERE s.nm_id = n.nm_id AND s.seller_id = a.seller_id AND s.updated_timestamp >= $4) OR
+				EXISTS (SELECT 1 FROM wb_marketplace_stocks m
+					WHERE m.nm_id = n.nm_id AND m.seller_id = a.seller_id AND m.updated_timestamp >= $4)), false)
+			FROM wb_adverts a JOIN wb_advert_nms n ON n.advert_id = a.advert_id AND n.seller_id = a.seller_id
+			WHERE a.status IN ($1, $2) AND a.seller_id = $3
+			GROUP BY a.advert_id, a.status, a.paused_by_stock`,
+		wbapi.AdvertStatusActive, wbapi.AdvertStatusPaused, p.sellerID, updatedFrom.UTC().Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*advertStock
+	for rows.Next() {
+		a := &advertStock{}
+		if err := rows.Scan(&a.advertID, &a.status, &a.pausedByStock, &a.stock, &a.fresh); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+// setAdvertPausedByStock записывает в БД статус кампании и признак приостановки из-за отсутствия остатков
+func (p *pClinet) setAdvertPausedByStock(advertID uint32, status int8, pausedByStock bool) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_adverts SET status = $2, paused_by_stock = $3, updated_timestamp = $4
+			WHERE advert_id = $1 AND seller_id = $5`,
+		advertID, status, pausedByStock, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении кампании %d в базе данных возникла ошибка %s", advertID, err.Error()))
+	}
+
+	return err
+}
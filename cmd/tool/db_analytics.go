@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// replacePaidStorage заменяет в БД данные платного хранения за период данными полученными с api
+func (p *pClinet) replacePaidStorage(dateFrom string, dateTo string, rows []*wbapi.PaidStorage) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	_, err = tx.Exec(
+		p.ctx, `DELETE FROM wb_paid_storage WHERE date BETWEEN $1 AND $2 AND seller_id = $3`, dateFrom, dateTo, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении данных платного хранения возникла ошибка %s", err.Error()))
+		return err
+	}
+
+	for _, row := range rows {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_paid_storage (date, nm_id, barcode, chrt_id, gi_id, vendor_code, subject, brand, size,
+				office_id, warehouse, warehouse_coef, log_warehouse_coef, volume, calc_type, warehouse_price,
+				barcodes_count, pallet_place_code, pallet_count, loyalty_discount, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)`,
+			row.Date, row.NmID, row.Barcode, row.ChrtID, row.GiID, row.VendorCode, row.Subject, row.Brand,
+			row.Size, row.OfficeID, row.Warehouse, row.WarehouseCoef, row.LogWarehouseCoef, row.Volume,
+			row.CalcType, row.WarehousePrice, row.BarcodesCount, row.PalletPlaceCode, row.PalletCount,
+			row.LoyaltyDiscount, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи платного хранения карточки %d в базу данных возникла ошибка %s", row.NmID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Данные платного хранения с %s по %s успешно синхронизировны", dateFrom, dateTo))
+
+	return nil
+}
+
+// replacePaidAcceptance заменяет в БД данные платной приемки за период данными полученными с api
+func (p *pClinet) replacePaidAcceptance(dateFrom string, dateTo string, rows []*wbapi.PaidAcceptance) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	_, err = tx.Exec(
+		p.ctx, `DELETE FROM wb_paid_acceptance WHERE shk_create_date BETWEEN $1 AND $2 AND seller_id = $3`,
+		dateFrom, dateTo, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении данных платной приемки возникла ошибка %s", err.Error()))
+		return err
+	}
+
+	for _, row := range rows {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_paid_acceptance (income_id, nm_id, subject_name, gi_create_date, shk_create_date, count, total, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			row.IncomeID, row.NmID, row.SubjectName, nullIfEmpty(row.GiCreateDate), row.ShkCreateDate,
+			row.Count, row.Total, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи платной приемки карточки %d в базу данных возникла ошибка %s", row.NmID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Данные платной приемки с %s по %s успешно синхронизировны", dateFrom, dateTo))
+
+	return nil
+}
+
+// syncNmReportHistory записывает показатели воронки продаж карточек по дням в БД
+func (p *pClinet) syncNmReportHistory(history []*wbapi.NmReportHistory) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, card := range history {
+		for _, day := range card.History {
+			_, err := tx.Exec(
+				p.ctx,
+				`INSERT INTO wb_nm_report_history (nm_id, date, open_card_count, add_to_cart_count, add_to_cart_conversion,
+					orders_count, orders_sum_rub, cart_to_order_conversion, buyouts_count, buyouts_sum_rub,
+					buyout_percent, updated_timestamp, seller_id)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+					ON CONFLICT (seller_id, nm_id, date) DO UPDATE
+						SET open_card_count = $3, add_to_cart_count = $4, add_to_cart_conversion = $5,
+							orders_count = $6, orders_sum_rub = $7, cart_to_order_conversion = $8,
+							buyouts_count = $9, buyouts_sum_rub = $10, buyout_percent = $11, updated_timestamp = $12`,
+				card.NmID, day.Dt, day.OpenCardCount, day.AddToCartCount, day.AddToCartConversion,
+				day.OrdersCount, day.OrdersSumRub, day.CartToOrderConversion, day.BuyoutsCount,
+				day.BuyoutsSumRub, day.BuyoutPercent, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+			)
+			if err != nil {
+				slog.Error(fmt.Sprintf("При записи воронки продаж карточки %d за %s в базу данных возникла ошибка %s", card.NmID, day.Dt, err.Error()))
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Воронка продаж успешно синхронизировна"))
+
+	return nil
+}
+
+// syncSearchTexts записывает поисковые запросы по карточкам за неделю в БД
+func (p *pClinet) syncSearchTexts(week string, texts []*wbapi.SearchText) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, text := range texts {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_search_texts (nm_id, week, text, frequency, week_frequency, avg_position, median_position,
+				open_card, add_to_cart, orders, visibility, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+				ON CONFLICT (seller_id, nm_id, week, text) DO UPDATE
+					SET frequency = $4, week_frequency = $5, avg_position = $6, median_position = $7,
+						open_card = $8, add_to_cart = $9, orders = $10, visibility = $11, updated_timestamp = $12`,
+			text.NmID, week, text.Text, text.Frequency.Current, text.WeekFrequency, text.AvgPosition.Current,
+			text.MedianPosition.Current, text.OpenCard.Current, text.AddToCart.Current, text.Orders.Current,
+			text.Visibility.Current, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи поискового запроса карточки %d в базу данных возникла ошибка %s", text.NmID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Поисковые запросы за неделю %s успешно синхронизировны", week))
+
+	return nil
+}
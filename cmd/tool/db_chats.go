@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// chatAttachment описывает вложение сообщения, которое еще не сохранено локально
+type chatAttachment struct {
+	eventID    string
+	downloadID string
+	name       string
+}
+
+// syncChats записывает чаты полученные с api в БД
+func (p *pClinet) syncChats(chats []*wbapi.Chat) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, c := range chats {
+		var nmID *uint32
+		var rid *string
+		if c.GoodCard != nil {
+			nmID = &c.GoodCard.NmID
+			rid = nullIfEmpty(c.GoodCard.Rid)
+		}
+
+		var lastText *string
+		var lastTime *string
+		if c.LastMessage != nil {
+			lastText = &c.LastMessage.Text
+			t := time.UnixMilli(c.LastMessage.AddTimestamp).UTC().Format("2006-01-02 15:04:05")
+			lastTime = &t
+		}
+
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_chats (chat_id, reply_sign, client_id, client_name, nm_id, rid, last_message_text,
+				last_message_time, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+				ON CONFLICT (seller_id, chat_id) DO UPDATE SET reply_sign = $2, client_name = $4,
+				nm_id = coalesce($5, wb_chats.nm_id), rid = coalesce($6, wb_chats.rid), last_message_text = $7,
+				last_message_time = $8, updated_timestamp = $9`,
+			c.ChatID, c.ReplySign, c.ClientID, c.ClientName, nmID, rid, lastText, lastTime,
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи чата %s в базу данных возникла ошибка %s", c.ChatID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Чаты с покупателями успешно синхронизировны"))
+
+	return nil
+}
+
+// getChatEventsCursor возвращает курсор для получения новых событий чатов.
+// Это время последнего сохраненного события, если событий нет, то 0
+func (p *pClinet) getChatEventsCursor() (int64, error) {
+	var next int64
+
+	err := p.pool.QueryRow(
+		p.ctx, `SELECT coalesce(max(add_timestamp), 0) FROM wb_chat_messages WHERE seller_id = $1`, p.sellerID,
+	).Scan(&next)
+
+	return next, err
+}
+
+// addChatEvents записывает события чатов и их вложения в БД. Уже сохраненные события пропускаются
+func (p *pClinet) addChatEvents(events []*wbapi.ChatEvent) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, e := range events {
+		var text *string
+		var nmID *uint32
+		var attachments []*wbapi.ChatFile
+		var kinds []string
+		if e.Message != nil {
+			text = &e.Message.Text
+			if a := e.Message.Attachments; a != nil {
+				if a.GoodCard != nil {
+					nmID = &a.GoodCard.NmID
+				}
+				for _, f := range a.Files {
+					attachments = append(attachments, f)
+					kinds = append(kinds, "file")
+				}
+				for _, f := range a.Images {
+					attachments = append(attachments, f)
+					kinds = append(kinds, "image")
+				}
+			}
+		}
+
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_chat_messages (event_id, chat_id, event_type, sender, source, client_name, nm_id, text,
+				add_timestamp, add_time, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+				ON CONFLICT (seller_id, event_id) DO NOTHING`,
+			e.EventID, e.ChatID, e.EventType, e.Sender, e.Source, e.ClientName, nmID, text, e.AddTimestamp,
+			time.UnixMilli(e.AddTimestamp).UTC().Format("2006-01-02 15:04:05"),
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи события чата %s в базу данных возникла ошибка %s", e.EventID, err.Error()))
+			return err
+		}
+
+		for i, f := range attachments {
+			_, err := tx.Exec(
+				p.ctx,
+				`INSERT INTO wb_chat_attachments (event_id, download_id, kind, name, content_type, size, url,
+					seller_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+					ON CONFLICT (seller_id, event_id, download_id) DO NOTHING`,
+				e.EventID, f.DownloadID, kinds[i], nullIfEmpty(f.Name), nullIfEmpty(f.ContentType), f.Size, f.URL,
+				p.sellerID,
+			)
+			if err != nil {
+				slog.Error(fmt.Sprintf("При записи вложения события чата %s в базу данных возникла ошибка %s", e.EventID, err.Error()))
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("События чатов успешно синхронизировны"))
+
+	return nil
+}
+
+// getChatAttachmentsToDownload возвращает вложения, которые еще не сохранены локально
+// и загрузка которых завершилась ошибкой менее maxAttempts раз
+func (p *pClinet) getChatAttachmentsToDownload(maxAttempts int) ([]*chatAttachment, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT event_id, download_id, coalesce(name, '') FROM wb_chat_attachments
+			WHERE local_path IS NULL AND download_attempts < $2 AND seller_id = $1`,
+		p.sellerID, maxAttempts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*chatAttachment
+	for rows.Next() {
+		a := &chatAttachment{}
+		if err := rows.Scan(&a.eventID, &a.downloadID, &a.name); err != nil {
+			return nil, err
+		}
+		result = append(result, a)
+	}
+
+	return result, rows.Err()
+}
+
+// setChatAttachmentPath записывает в БД путь к локально сохраненному вложению
+func (p *pClinet) setChatAttachmentPath(a *chatAttachment, path string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_chat_attachments SET local_path = $3 WHERE event_id = $1 AND download_id = $2 AND seller_id = $4`,
+		a.eventID, a.downloadID, path, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении вложения %s в базе данных возникла ошибка %s", a.downloadID, err.Error()))
+	}
+
+	return err
+}
+
+// setChatAttachmentError записывает в БД ошибку загрузки вложения и увеличивает счетчик попыток
+func (p *pClinet) setChatAttachmentError(a *chatAttachment, downloadErr error) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_chat_attachments SET download_attempts = download_attempts + 1, download_error = $3
+			WHERE event_id = $1 AND download_id = $2 AND seller_id = $4`,
+		a.eventID, a.downloadID, downloadErr.Error(), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении вложения %s в базе данных возникла ошибка %s", a.downloadID, err.Error()))
+	}
+
+	return err
+}
+
+// getChatReplySign возвращает подпись для ответа в чат
+func (p *pClinet) getChatReplySign(chatID string) (string, error) {
+	var replySign string
+
+	err := p.pool.QueryRow(
+		p.ctx, `SELECT reply_sign FROM wb_chats WHERE chat_id = $1 AND seller_id = $2`, chatID, p.sellerID,
+	).Scan(&replySign)
+
+	return replySign, err
+}
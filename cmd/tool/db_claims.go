@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// syncClaims записывает заявки на возврат полученные с api в БД.
+// Баркод товара заполняется по заказу с тем же srid из таблицы wb_orders
+func (p *pClinet) syncClaims(claims []*wbapi.Claim, archived bool) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, c := range claims {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_claims (claim_id, claim_type, status, status_ex, nm_id, sku, srid, imt_name, user_comment,
+				wb_comment, price, currency_code, photos, video_paths, actions, archived, dt, order_dt, dt_update,
+				updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, (SELECT barcode FROM wb_orders WHERE srid = $6 AND seller_id = $20), $6, $7,
+				$8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+				ON CONFLICT (seller_id, claim_id) DO UPDATE SET status = $3, status_ex = $4,
+				sku = coalesce(wb_claims.sku, EXCLUDED.sku), wb_comment = $9, actions = $14, archived = $15,
+				dt_update = $18, updated_timestamp = $19`,
+			c.ID, c.ClaimType, c.Status, c.StatusEx, c.NmID, c.Srid, c.ImtName, c.UserComment, c.WbComment, c.Price,
+			c.CurrencyCode, c.Photos, c.VideoPaths, c.Actions, archived, c.Dt, nullIfEmpty(c.OrderDt),
+			nullIfEmpty(c.DtUpdate), time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи заявки %s в базу данных возникла ошибка %s", c.ID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Заявки на возврат успешно синхронизировны"))
+
+	return nil
+}
+
+// markClaimAnswered записывает в БД ответ на заявку
+func (p *pClinet) markClaimAnswered(id string, action string, comment string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_claims SET answer_action = $2, answer_comment = $3, updated_timestamp = $4
+			WHERE claim_id = $1 AND seller_id = $5`,
+		id, action, nullIfEmpty(comment), time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении заявки %s в базе данных возникла ошибка %s", id, err.Error()))
+	}
+
+	return err
+}
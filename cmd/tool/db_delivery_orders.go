@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// deliveryFinalStatuses статусы продавца и WB, после которых статус сборочного задания больше не меняется
+var deliveryFinalStatuses = []string{
+	wbapi.DeliveryStatusReceive, wbapi.DeliveryStatusReject, wbapi.DeliveryStatusCancel,
+	"sold", "canceled", "canceled_by_client", "declined_by_client", "defect",
+}
+
+// deliveryOrder описывает сборочное задание DBS или самовывоза из БД
+type deliveryOrder struct {
+	orderID        uint64
+	nmID           uint32
+	article        string
+	price          uint32
+	address        *string
+	deliveryDate   *string
+	supplierStatus string
+	wbStatus       *string
+}
+
+// addDeliveryOrders записывает новые сборочные задания схемы flow в БД и возвращает задания,
+// которых раньше не было в БД
+func (p *pClinet) addDeliveryOrders(flow wbapi.DeliveryFlow, orders []*wbapi.DeliveryOrder) ([]*wbapi.DeliveryOrder, error) {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	updated := time.Now().UTC().Format("2006-01-02 15:04:05")
+
+	var added []*wbapi.DeliveryOrder
+	for _, o := range orders {
+		var sku, address string
+		if len(o.Skus) > 0 {
+			sku = o.Skus[0]
+		}
+		if o.Address != nil {
+			address = o.Address.FullAddress
+		}
+
+		tag, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_delivery_orders (order_id, flow, rid, order_uid, group_id, nm_id, sku, article, warehouse_id,
+				price, converted_price, currency_code, address, comment, delivery_date, delivery_time_from,
+				delivery_time_to, supplier_status, created_at, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+				ON CONFLICT (seller_id, order_id) DO NOTHING`,
+			o.ID, string(flow), nullIfEmpty(o.Rid), nullIfEmpty(o.OrderUID), nullIfEmpty(o.GroupID), o.NmID, nullIfEmpty(sku),
+			o.Article, o.WarehouseID, o.Price, o.ConvertedPrice, o.CurrencyCode, nullIfEmpty(address),
+			nullIfEmpty(o.Comment), nullIfEmpty(o.DDate), nullIfEmpty(o.DTimeFrom), nullIfEmpty(o.DTimeTo),
+			wbapi.DeliveryStatusNew, nullIfEmpty(o.CreatedAt), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи сборочного задания %d в базу данных возникла ошибка %s", o.ID, err.Error()))
+			return nil, err
+		}
+
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_delivery_order_statuses (order_id, supplier_status, changed_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4)`,
+			o.ID, wbapi.DeliveryStatusNew, updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи статуса сборочного задания %d возникла ошибка %s", o.ID, err.Error()))
+			return nil, err
+		}
+
+		added = append(added, o)
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// getOpenDeliveryOrderIDs возвращает ID сборочных заданий схемы flow, статус которых еще может измениться
+func (p *pClinet) getOpenDeliveryOrderIDs(flow wbapi.DeliveryFlow) ([]uint64, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT order_id FROM wb_delivery_orders
+			WHERE flow = $1 AND supplier_status <> ALL($2) AND coalesce(wb_status, '') <> ALL($2) AND seller_id = $3`,
+		string(flow), deliveryFinalStatuses, p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []uint64
+	for rows.Next() {
+		var id uint64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result = append(result, id)
+	}
+
+	return result, rows.Err()
+}
+
+// updateDeliveryOrderStatuses записывает в БД изменившиеся статусы сборочных заданий и историю изменений
+func (p *pClinet) updateDeliveryOrderStatuses(statuses []*wbapi.DeliveryOrderStatus) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	updated := time.Now().UTC().Format("2006-01-02 15:04:05")
+
+	for _, s := range statuses {
+		tag, err := tx.Exec(
+			p.ctx,
+			`UPDATE wb_delivery_orders SET supplier_status = $2, wb_status = $3, updated_timestamp = $4
+				WHERE order_id = $1 AND seller_id = $5 AND (supplier_status <> $2 OR wb_status IS DISTINCT FROM $3)`,
+			s.ID, s.SupplierStatus, nullIfEmpty(s.WbStatus), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При обновлении статуса сборочного задания %d возникла ошибка %s", s.ID, err.Error()))
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			continue
+		}
+
+		_, err = tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_delivery_order_statuses (order_id, supplier_status, wb_status, changed_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5)`,
+			s.ID, s.SupplierStatus, nullIfEmpty(s.WbStatus), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи статуса сборочного задания %d возникла ошибка %s", s.ID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Статусы сборочных заданий успешно синхронизировны"))
+
+	return nil
+}
+
+// getOpenDeliveryOrders возвращает сборочные задания схемы flow, статус которых еще может измениться
+func (p *pClinet) getOpenDeliveryOrders(flow wbapi.DeliveryFlow) ([]*deliveryOrder, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT order_id, nm_id, coalesce(article, ''), coalesce(converted_price, 0), address, delivery_date,
+			supplier_status, wb_status
+			FROM wb_delivery_orders
+			WHERE flow = $1 AND supplier_status <> ALL($2) AND coalesce(wb_status, '') <> ALL($2) AND seller_id = $3
+			ORDER BY created_at`,
+		string(flow), deliveryFinalStatuses, p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*deliveryOrder
+	for rows.Next() {
+		o := &deliveryOrder{}
+		if err := rows.Scan(
+			&o.orderID, &o.nmID, &o.article, &o.price, &o.address, &o.deliveryDate, &o.supplierStatus, &o.wbStatus,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, o)
+	}
+
+	return result, rows.Err()
+}
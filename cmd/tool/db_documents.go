@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// documentFile описывает документ в указанном формате, который еще не сохранен в архив
+type documentFile struct {
+	serviceName string
+	extension   string
+	category    string
+}
+
+// syncDocuments записывает документы полученные с api в БД. Каждый формат документа хранится отдельной строкой
+func (p *pClinet) syncDocuments(documents []*wbapi.Document) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, d := range documents {
+		for _, extension := range d.Extensions {
+			_, err := tx.Exec(
+				p.ctx,
+				`INSERT INTO wb_documents (service_name, extension, name, category, creation_time, viewed, updated_timestamp,
+					seller_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+					ON CONFLICT (seller_id, service_name, extension) DO UPDATE SET name = $3, category = $4, viewed = $6,
+					updated_timestamp = $7`,
+				d.ServiceName, extension, d.Name, d.Category, nullIfEmpty(d.CreationTime), d.Viewed,
+				time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+			)
+			if err != nil {
+				slog.Error(fmt.Sprintf("При записи документа %s в базу данных возникла ошибка %s", d.ServiceName, err.Error()))
+				return err
+			}
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Документы успешно синхронизировны"))
+
+	return nil
+}
+
+// getDocumentsToDownload возвращает документы в указанных форматах, которые еще не сохранены в архив
+// и загрузка которых завершилась ошибкой менее maxAttempts раз
+func (p *pClinet) getDocumentsToDownload(extensions []string, maxAttempts int) ([]*documentFile, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT service_name, extension, coalesce(category, '') FROM wb_documents
+			WHERE local_path IS NULL AND extension = ANY($1) AND download_attempts < $3 AND seller_id = $2
+			ORDER BY creation_time`,
+		extensions, p.sellerID, maxAttempts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*documentFile
+	for rows.Next() {
+		d := &documentFile{}
+		if err := rows.Scan(&d.serviceName, &d.extension, &d.category); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
+
+// setDocumentPath записывает в БД путь к документу в архиве
+func (p *pClinet) setDocumentPath(d *documentFile, path string) error {
+	now := time.Now().UTC().Format("2006-01-02 15:04:05")
+
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_documents SET local_path = $3, downloaded_timestamp = $4, updated_timestamp = $4
+			WHERE service_name = $1 AND extension = $2 AND seller_id = $5`,
+		d.serviceName, d.extension, path, now, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении документа %s в базе данных возникла ошибка %s", d.serviceName, err.Error()))
+	}
+
+	return err
+}
+
+// setDocumentError записывает в БД ошибку загрузки документа и увеличивает счетчик попыток
+func (p *pClinet) setDocumentError(d *documentFile, downloadErr error) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_documents SET download_attempts = download_attempts + 1, download_error = $3, updated_timestamp = $4
+			WHERE service_name = $1 AND extension = $2 AND seller_id = $5`,
+		d.serviceName, d.extension, downloadErr.Error(), time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении документа %s в базе данных возникла ошибка %s", d.serviceName, err.Error()))
+	}
+
+	return err
+}
+
+// upsertBalance записывает в БД баланс продавца за текущий день
+func (p *pClinet) upsertBalance(balance *wbapi.Balance) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_balance (date, currency, current, for_withdraw, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (seller_id, date) DO UPDATE SET currency = $2, current = $3, for_withdraw = $4, updated_timestamp = $5`,
+		time.Now().Format("2006-01-02"), balance.Currency, balance.Current, balance.ForWithdraw,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи баланса продавца в базу данных возникла ошибка %s", err.Error()))
+	}
+
+	return err
+}
@@ -0,0 +1,331 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// unansweredFeedback описывает строку представления wb_feedbacks_unanswered
+type unansweredFeedback struct {
+	kind            string
+	id              string
+	nmID            uint32
+	supplierArticle string
+	valuation       *int16
+	text            string
+	waiting         time.Duration
+}
+
+// syncFeedbacks записывает отзывы полученные с api в БД
+func (p *pClinet) syncFeedbacks(feedbacks []*wbapi.Feedback) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, f := range feedbacks {
+		var answerText *string
+		var answerEditable *bool
+		if f.Answer != nil {
+			answerText = &f.Answer.Text
+			answerEditable = &f.Answer.Editable
+		}
+
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_feedbacks (feedback_id, nm_id, imt_id, supplier_article, product_name, brand_name,
+				subject_name, size, user_name, text, pros, cons, product_valuation, created_date, state, was_viewed,
+				answered, answer_text, answer_editable, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+				ON CONFLICT (seller_id, feedback_id) DO UPDATE SET text = $10, pros = $11, cons = $12, product_valuation = $13,
+				state = $15, was_viewed = $16, answered = $17, answer_text = $18, answer_editable = $19,
+				updated_timestamp = $20`,
+			f.ID, f.ProductDetails.NmID, f.ProductDetails.ImtID, f.ProductDetails.SupplierArticle,
+			f.ProductDetails.ProductName, f.ProductDetails.BrandName, f.SubjectName, f.ProductDetails.Size,
+			f.UserName, f.Text, f.Pros, f.Cons, f.ProductValuation, f.CreatedDate, f.State, f.WasViewed,
+			f.Answer != nil, answerText, answerEditable, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи отзыва %s в базу данных возникла ошибка %s", f.ID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Отзывы успешно синхронизировны"))
+
+	return nil
+}
+
+// syncQuestions записывает вопросы полученные с api в БД
+func (p *pClinet) syncQuestions(questions []*wbapi.Question) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, q := range questions {
+		var answerText *string
+		var answerEditable *bool
+		if q.Answer != nil {
+			answerText = &q.Answer.Text
+			answerEditable = &q.Answer.Editable
+		}
+
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_questions (question_id, nm_id, imt_id, supplier_article, product_name, brand_name, size,
+				text, created_date, state, was_viewed, answered, answer_text, answer_editable, updated_timestamp,
+				seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+				ON CONFLICT (seller_id, question_id) DO UPDATE SET text = $8, state = $10, was_viewed = $11, answered = $12,
+				answer_text = $13, answer_editable = $14, updated_timestamp = $15`,
+			q.ID, q.ProductDetails.NmID, q.ProductDetails.ImtID, q.ProductDetails.SupplierArticle,
+			q.ProductDetails.ProductName, q.ProductDetails.BrandName, q.ProductDetails.Size, q.Text,
+			q.CreatedDate, q.State, q.WasViewed, q.Answer != nil, answerText, answerEditable,
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи вопроса %s в базу данных возникла ошибка %s", q.ID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Вопросы успешно синхронизировны"))
+
+	return nil
+}
+
+// markFeedbackAnswered отмечает в БД отзыв обработанным
+func (p *pClinet) markFeedbackAnswered(id string, text string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_feedbacks SET answered = true, answer_text = $2, was_viewed = true, updated_timestamp = $3
+			WHERE feedback_id = $1 AND seller_id = $4`,
+		id, text, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении отзыва %s в базе данных возникла ошибка %s", id, err.Error()))
+	}
+
+	return err
+}
+
+// markQuestionAnswered отмечает в БД вопрос обработанным
+func (p *pClinet) markQuestionAnswered(id string, text string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_questions SET answered = true, answer_text = $2, was_viewed = true, updated_timestamp = $3
+			WHERE question_id = $1 AND seller_id = $4`,
+		id, text, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении вопроса %s в базе данных возникла ошибка %s", id, err.Error()))
+	}
+
+	return err
+}
+
+// markFeedbackViewed отмечает в БД отзыв просмотренным
+func (p *pClinet) markFeedbackViewed(id string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_feedbacks SET was_viewed = true, updated_timestamp = $2 WHERE feedback_id = $1 AND seller_id = $3`,
+		id, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении отзыва %s в базе данных возникла ошибка %s", id, err.Error()))
+	}
+
+	return err
+}
+
+// markQuestionViewed отмечает в БД вопрос просмотренным
+func (p *pClinet) markQuestionViewed(id string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_questions SET was_viewed = true, updated_timestamp = $2 WHERE question_id = $1 AND seller_id = $3`,
+		id, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении вопроса %s в базе данных возникла ошибка %s", id, err.Error()))
+	}
+
+	return err
+}
+
+// markMissingFeedbacksAnswered отмечает обработанными необработанные в БД отзывы, которых нет в списке
+// необработанных отзывов ids полученном с api. Так обрабатываются отзывы, на которые ответили в кабинете
+// после периода синхронизации feedbacks.sync_days
+func (p *pClinet) markMissingFeedbacksAnswered(ids []string) error {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	tag, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_feedbacks SET answered = true, updated_timestamp = $2
+			WHERE NOT answered AND seller_id = $3 AND NOT (feedback_id = ANY($1))`,
+		ids, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении обработанных отзывов в базе данных возникла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Отмечено обработанными %d отзывов, которых нет в списке необработанных", tag.RowsAffected()))
+
+	return nil
+}
+
+// markMissingQuestionsAnswered отмечает обработанными необработанные в БД вопросы, которых нет в списке
+// необработанных вопросов ids полученном с api
+func (p *pClinet) markMissingQuestionsAnswered(ids []string) error {
+	if ids == nil {
+		ids = []string{}
+	}
+
+	tag, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_questions SET answered = true, updated_timestamp = $2
+			WHERE NOT answered AND seller_id = $3 AND NOT (question_id = ANY($1))`,
+		ids, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении обработанных вопросов в базе данных возникла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Отмечено обработанными %d вопросов, которых нет в списке необработанных", tag.RowsAffected()))
+
+	return nil
+}
+
+// getUnansweredFeedbacks возвращает необработанные отзывы и вопросы, начиная с самых старых
+func (p *pClinet) getUnansweredFeedbacks() ([]*unansweredFeedback, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT kind, id, nm_id, coalesce(supplier_article, ''), product_valuation, coalesce(text, ''), waiting
+			FROM wb_feedbacks_unanswered WHERE seller_id = $1 ORDER BY created_date`,
+		p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*unansweredFeedback
+	for rows.Next() {
+		f := &unansweredFeedback{}
+		if err := rows.Scan(&f.kind, &f.id, &f.nmID, &f.supplierArticle, &f.valuation, &f.text, &f.waiting); err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+
+	return result, rows.Err()
+}
+
+// feedbackForReply описывает необработанный отзыв с данными карточки для подстановки в шаблон ответа
+type feedbackForReply struct {
+	id           string
+	nmID         uint32
+	valuation    uint8
+	userName     string
+	productTitle string
+	subjectName  string
+	brand        string
+	text         string
+}
+
+// getFeedbacksForReply возвращает необработанные отзывы, начиная с самых старых.
+// Отзывы, для которых в журнале уже есть автоответ с тем же признаком dryRun, не возвращаются.
+// Название, предмет и бренд берутся из карточки, а если карточки нет, то из отзыва
+func (p *pClinet) getFeedbacksForReply(dryRun bool) ([]*feedbackForReply, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT f.feedback_id, f.nm_id, coalesce(f.product_valuation, 0), coalesce(f.user_name, ''),
+			coalesce(c.title, f.product_name, ''), coalesce(c.subject_name, f.subject_name, ''),
+			coalesce(c.brand, f.brand_name, ''), concat_ws(' ', f.text, f.pros, f.cons)
+			FROM wb_feedbacks f LEFT JOIN wb_content_cards c ON c.nm_id = f.nm_id AND c.seller_id = f.seller_id
+			WHERE NOT f.answered AND f.seller_id = $1
+			AND NOT EXISTS (SELECT 1 FROM wb_feedback_auto_replies r
+				WHERE r.seller_id = f.seller_id AND r.feedback_id = f.feedback_id AND r.dry_run = $2)
+			ORDER BY f.created_date`,
+		p.sellerID, dryRun,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*feedbackForReply
+	for rows.Next() {
+		f := &feedbackForReply{}
+		err := rows.Scan(&f.id, &f.nmID, &f.valuation, &f.userName, &f.productTitle, &f.subjectName, &f.brand, &f.text)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, f)
+	}
+
+	return result, rows.Err()
+}
+
+// getAutoRepliesCountToday возвращает количество отправленных сегодня автоответов по правилам
+func (p *pClinet) getAutoRepliesCountToday() (map[string]int, error) {
+	year, month, day := time.Now().Date()
+
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT rule, count(*) FROM wb_feedback_auto_replies
+			WHERE NOT dry_run AND created_timestamp >= $1 AND seller_id = $2 GROUP BY rule`,
+		time.Date(year, month, day, 0, 0, 0, 0, time.Local).UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var rule string
+		var count int
+		if err := rows.Scan(&rule, &count); err != nil {
+			return nil, err
+		}
+		counts[rule] = count
+	}
+
+	return counts, rows.Err()
+}
+
+// addAutoReplyLog записывает в журнал автоответ на отзыв. Повторный автоответ с тем же признаком dryRun
+// в журнал не записывается
+func (p *pClinet) addAutoReplyLog(f *feedbackForReply, rule string, text string, dryRun bool) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_feedback_auto_replies (feedback_id, nm_id, product_valuation, rule, text, dry_run, created_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (seller_id, feedback_id, dry_run) DO NOTHING`,
+		f.id, f.nmID, f.valuation, rule, text, dryRun, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи автоответа на отзыв %s в базу данных возникла ошибка %s", f.id, err.Error()))
+	}
+
+	return err
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/jackc/pgx/v5"
+)
+
+// penalty описывает удержание или штраф в таблице wb_penalties
+type penalty struct {
+	kind   string
+	id     string
+	nmID   uint32
+	date   string
+	amount float64
+	reason string
+}
+
+// addPenalties записывает удержания и штрафы в БД и возвращает те, которых в БД еще не было
+func (p *pClinet) addPenalties(penalties []*penalty) ([]*penalty, error) {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	var added []*penalty
+	for _, pen := range penalties {
+		tag, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_penalties (kind, penalty_id, nm_id, date, amount, reason, created_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				ON CONFLICT (seller_id, kind, penalty_id) DO NOTHING`,
+			pen.kind, pen.id, pen.nmID, pen.date, pen.amount, nullIfEmpty(pen.reason),
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи удержания %s %s в базу данных возникла ошибка %s", pen.kind, pen.id, err.Error()))
+			return nil, err
+		}
+
+		if tag.RowsAffected() > 0 {
+			added = append(added, pen)
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Удержания успешно синхронизировны, новых %d", len(added)))
+
+	return added, nil
+}
+
+// upsertSellerRating записывает в БД рейтинг продавца за текущий день и возвращает рейтинг за предыдущий день,
+// если он есть
+func (p *pClinet) upsertSellerRating(
+	valuation *float64, feedbacks *wbapi.FeedbacksCountUnanswered, questions *wbapi.FeedbacksCountUnanswered,
+) (*float64, error) {
+	today := time.Now().Format("2006-01-02")
+
+	var previous *float64
+	err := p.pool.QueryRow(
+		p.ctx,
+		`SELECT valuation::float8 FROM wb_seller_rating WHERE date < $1 AND seller_id = $2 ORDER BY date DESC LIMIT 1`,
+		today, p.sellerID,
+	).Scan(&previous)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	_, err = p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_seller_rating (date, valuation, feedbacks_unanswered, questions_unanswered, updated_timestamp,
+			seller_id) VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (seller_id, date) DO UPDATE SET valuation = $2, feedbacks_unanswered = $3, questions_unanswered = $4,
+			updated_timestamp = $5`,
+		today, valuation, feedbacks.CountUnanswered, questions.CountUnanswered,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи рейтинга продавца в базу данных возникла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	return previous, nil
+}
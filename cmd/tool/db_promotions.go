@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// promotionCard описывает данные карточки для расчета маржи в акции
+type promotionCard struct {
+	nmID       uint32
+	costPrice  *float64
+	commission *float64
+	stock      int
+}
+
+// promotionDeadline описывает акцию, о начале которой нужно напомнить
+type promotionDeadline struct {
+	id    uint32
+	name  string
+	start time.Time
+}
+
+// syncPromotions записывает акции полученные с api в БД
+func (p *pClinet) syncPromotions(promotions []*wbapi.Promotion) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, pr := range promotions {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_promotions (promotion_id, name, type, description, start_timestamp, end_timestamp,
+				in_action_total, not_in_action_total, participation_percentage, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+				ON CONFLICT (seller_id, promotion_id) DO UPDATE SET name = $2, type = $3, description = $4, start_timestamp = $5,
+				end_timestamp = $6, in_action_total = $7, not_in_action_total = $8, participation_percentage = $9,
+				updated_timestamp = $10`,
+			pr.ID, pr.Name, pr.Type, nullIfEmpty(pr.Description), pr.StartDateTime, pr.EndDateTime,
+			pr.InPromoActionTotal, pr.NotInPromoActionTotal, pr.ParticipationPercentage,
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи акции %d в базу данных возникла ошибка %s", pr.ID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Акции успешно синхронизировны"))
+
+	return nil
+}
+
+// getPromotionDeadlines возвращает акции, которые начнутся до until и о которых еще не было уведомления.
+// Акции, в которые уже добавлялись карточки, не возвращаются
+func (p *pClinet) getPromotionDeadlines(until time.Time) ([]*promotionDeadline, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT promotion_id, name, start_timestamp FROM wb_promotions p
+			WHERE NOT notified AND type <> $1 AND start_timestamp BETWEEN $2 AND $3 AND seller_id = $4
+			AND NOT EXISTS (SELECT 1 FROM wb_promotion_uploads u
+				WHERE u.promotion_id = p.promotion_id AND u.seller_id = p.seller_id)
+			ORDER BY start_timestamp`,
+		wbapi.PromotionTypeAuto, time.Now().UTC().Format("2006-01-02 15:04:05"), until.UTC().Format("2006-01-02 15:04:05"),
+		p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*promotionDeadline
+	for rows.Next() {
+		d := &promotionDeadline{}
+		if err := rows.Scan(&d.id, &d.name, &d.start); err != nil {
+			return nil, err
+		}
+		result = append(result, d)
+	}
+
+	return result, rows.Err()
+}
+
+// markPromotionsNotified отмечает в БД акции, о которых отправлено уведомление
+func (p *pClinet) markPromotionsNotified(ids []uint32) error {
+	_, err := p.pool.Exec(
+		p.ctx, `UPDATE wb_promotions SET notified = true WHERE promotion_id = ANY($1) AND seller_id = $2`, ids, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении акций в базе данных возникла ошибка %s", err.Error()))
+	}
+
+	return err
+}
+
+// getPromotionCards возвращает себестоимость, комиссию за продажу со склада WB и остаток на складах WB
+// для карточек nmIDs
+func (p *pClinet) getPromotionCards(nmIDs []uint32) (map[uint32]*promotionCard, error) {
+	rows, err := p.pool.Query(
+		p.ctx,
+		`SELECT c.nm_id, c.cost_price, m.paid_storage_kgvp,
+			(SELECT coalesce(sum(s.quantity), 0) FROM wb_stocks s WHERE s.nm_id = c.nm_id AND s.seller_id = c.seller_id)
+			FROM wb_content_cards c
+			LEFT JOIN wb_content_cards_commission m ON m.nm_id = c.nm_id AND m.seller_id = c.seller_id
+			WHERE c.nm_id = ANY($1) AND c.seller_id = $2`,
+		nmIDs, p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[uint32]*promotionCard)
+	for rows.Next() {
+		c := &promotionCard{}
+		if err := rows.Scan(&c.nmID, &c.costPrice, &c.commission, &c.stock); err != nil {
+			return nil, err
+		}
+		result[c.nmID] = c
+	}
+
+	return result, rows.Err()
+}
+
+// addPromotionUpload записывает в БД загрузку карточек в акцию
+func (p *pClinet) addPromotionUpload(uploadID uint64, promotionID uint32, nmIDs []uint32) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_promotion_uploads (upload_id, promotion_id, nm_ids, created_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5) ON CONFLICT (seller_id, upload_id) DO NOTHING`,
+		uploadID, promotionID, nmIDs, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи загрузки в акцию %d возникла ошибка %s", promotionID, err.Error()))
+	}
+
+	return err
+}
+
+// setCardCostPrice записывает в БД себестоимость карточки
+func (p *pClinet) setCardCostPrice(nmID uint32, costPrice float64) error {
+	tag, err := p.pool.Exec(
+		p.ctx, `UPDATE wb_content_cards SET cost_price = $2 WHERE nm_id = $1 AND seller_id = $3`, nmID, costPrice, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При обновлении карточки %d в базе данных возникла ошибка %s", nmID, err.Error()))
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("карточка %d не найдена", nmID)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// forSeller возвращает подключение к БД, запросы которого ограничены данными кабинета sellerID
+func (p *pClinet) forSeller(sellerID int32) *pClinet {
+	return &pClinet{pool: p.pool, ctx: p.ctx, sellerID: sellerID}
+}
+
+// upsertSeller записывает кабинет продавца в БД и возвращает его ID
+func (p *pClinet) upsertSeller(name string, tokenEnv string) (int32, error) {
+	var id int32
+
+	err := p.pool.QueryRow(
+		p.ctx,
+		`INSERT INTO wb_sellers (name, token_env, created_timestamp) VALUES ($1, $2, $3)
+			ON CONFLICT (name) DO UPDATE SET token_env = $2 RETURNING seller_id`,
+		name, nullIfEmpty(tokenEnv), time.Now().UTC().Format("2006-01-02 15:04:05"),
+	).Scan(&id)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи кабинета %s в базу данных возникла ошибка %s", name, err.Error()))
+	}
+
+	return id, err
+}
+
+// claimDefaultSeller передает кабинету name данные кабинета default, загруженные до появления кабинетов.
+// Данные передаются, только если кабинета name еще нет в БД
+func (p *pClinet) claimDefaultSeller(name string) error {
+	tag, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_sellers SET name = $1 WHERE name = $2 AND NOT EXISTS (SELECT 1 FROM wb_sellers WHERE name = $1)`,
+		name, defaultSellerName,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При передаче данных кабинета %s кабинету %s возникла ошибка %s", defaultSellerName, name, err.Error()))
+		return err
+	}
+
+	if tag.RowsAffected() > 0 {
+		slog.Info(fmt.Sprintf("Данные кабинета %s переданы кабинету %s", defaultSellerName, name))
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/jackc/pgx/v5"
+)
+
+// getStatisticsDateFrom возвращает дату, с которой нужно запрашивать изменения для таблицы статистики.
+// Это максимальное значение last_change_date в таблице, если таблица пустая, то настройка statistics.date_from
+func (p *pClinet) getStatisticsDateFrom(table string) (string, error) {
+	var lastChangeDate *time.Time
+
+	err := p.pool.QueryRow(
+		p.ctx, fmt.Sprintf("SELECT max(last_change_date) FROM %s WHERE seller_id = $1", pgx.Identifier{table}.Sanitize()),
+		p.sellerID,
+	).Scan(&lastChangeDate)
+	if err != nil {
+		return "", err
+	}
+
+	if lastChangeDate == nil {
+		return config.GetString("statistics.date_from"), nil
+	}
+
+	return lastChangeDate.Format("2006-01-02T15:04:05"), nil
+}
+
+// syncStatisticsOrders записывает заказы полученные с api в БД
+func (p *pClinet) syncStatisticsOrders(orders []*wbapi.StatisticsOrder) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, order := range orders {
+		if err := p.upsertStatisticsOrder(tx, order); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Заказы успешно синхронизировны"))
+
+	return nil
+}
+
+// upsertStatisticsOrder добавляет или обновляет запись заказа в БД
+func (p *pClinet) upsertStatisticsOrder(tx pgx.Tx, order *wbapi.StatisticsOrder) error {
+	_, err := tx.Exec(
+		p.ctx,
+		`INSERT INTO wb_orders (srid, g_number, date, last_change_date, warehouse_name, warehouse_type,
+			country_name, oblast_okrug_name, region_name, supplier_article, nm_id, barcode, category, subject,
+			brand, tech_size, income_id, is_supply, is_realization, total_price, discount_percent, spp,
+			finished_price, price_with_disc, is_cancel, cancel_date, order_type, sticker, updated_timestamp,
+			seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+				$21, $22, $23, $24, $25, $26, $27, $28, $29, $30)
+			ON CONFLICT (seller_id, srid) DO UPDATE
+				SET g_number = $2, date = $3, last_change_date = $4, warehouse_name = $5, warehouse_type = $6,
+					country_name = $7, oblast_okrug_name = $8, region_name = $9, supplier_article = $10,
+					nm_id = $11, barcode = $12, category = $13, subject = $14, brand = $15, tech_size = $16,
+					income_id = $17, is_supply = $18, is_realization = $19, total_price = $20,
+					discount_percent = $21, spp = $22, finished_price = $23, price_with_disc = $24,
+					is_cancel = $25, cancel_date = $26, order_type = $27, sticker = $28, updated_timestamp = $29`,
+		order.Srid, order.GNumber, order.Date, order.LastChangeDate, order.WarehouseName, order.WarehouseType,
+		order.CountryName, order.OblastOkrugName, order.RegionName, order.SupplierArticle, order.NmID,
+		order.Barcode, order.Category, order.Subject, order.Brand, order.TechSize, order.IncomeID,
+		order.IsSupply, order.IsRealization, order.TotalPrice, order.DiscountPercent, order.Spp,
+		order.FinishedPrice, order.PriceWithDisc, order.IsCancel, nullIfZeroDate(order.CancelDate),
+		order.OrderType, order.Sticker, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи заказа %s в базу данных возникла ошибка %s", order.Srid, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// syncStatisticsSales записывает продажи и возвраты полученные с api в БД
+func (p *pClinet) syncStatisticsSales(sales []*wbapi.StatisticsSale) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, sale := range sales {
+		if err := p.upsertStatisticsSale(tx, sale); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Продажи и возвраты успешно синхронизировны"))
+
+	return nil
+}
+
+// upsertStatisticsSale добавляет или обновляет запись продажи или возврата в БД
+func (p *pClinet) upsertStatisticsSale(tx pgx.Tx, sale *wbapi.StatisticsSale) error {
+	_, err := tx.Exec(
+		p.ctx,
+		`INSERT INTO wb_sales (sale_id, srid, g_number, date, last_change_date, warehouse_name, warehouse_type,
+			country_name, oblast_okrug_name, region_name, supplier_article, nm_id, barcode, category, subject,
+			brand, tech_size, income_id, is_supply, is_realization, total_price, discount_percent, spp,
+			payment_sale_amount, for_pay, finished_price, price_with_disc, order_type, sticker, updated_timestamp,
+			seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+				$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+			ON CONFLICT (seller_id, sale_id) DO UPDATE
+				SET srid = $2, g_number = $3, date = $4, last_change_date = $5, warehouse_name = $6,
+					warehouse_type = $7, country_name = $8, oblast_okrug_name = $9, region_name = $10,
+					supplier_article = $11, nm_id = $12, barcode = $13, category = $14, subject = $15,
+					brand = $16, tech_size = $17, income_id = $18, is_supply = $19, is_realization = $20,
+					total_price = $21, discount_percent = $22, spp = $23, payment_sale_amount = $24,
+					for_pay = $25, finished_price = $26, price_with_disc = $27, order_type = $28,
+					sticker = $29, updated_timestamp = $30`,
+		sale.SaleID, sale.Srid, sale.GNumber, sale.Date, sale.LastChangeDate, sale.WarehouseName,
+		sale.WarehouseType, sale.CountryName, sale.OblastOkrugName, sale.RegionName, sale.SupplierArticle,
+		sale.NmID, sale.Barcode, sale.Category, sale.Subject, sale.Brand, sale.TechSize, sale.IncomeID,
+		sale.IsSupply, sale.IsRealization, sale.TotalPrice, sale.DiscountPercent, sale.Spp,
+		sale.PaymentSaleAmount, sale.ForPay, sale.FinishedPrice, sale.PriceWithDisc, sale.OrderType,
+		sale.Sticker, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи продажи %s в базу данных возникла ошибка %s", sale.SaleID, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// syncStatisticsIncomes записывает поставки на склады WB полученные с api в БД
+func (p *pClinet) syncStatisticsIncomes(incomes []*wbapi.StatisticsIncome) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, income := range incomes {
+		if err := p.upsertStatisticsIncome(tx, income); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Поставки успешно синхронизировны"))
+
+	return nil
+}
+
+// upsertStatisticsIncome добавляет или обновляет запись поставки на склад WB в БД
+func (p *pClinet) upsertStatisticsIncome(tx pgx.Tx, income *wbapi.StatisticsIncome) error {
+	_, err := tx.Exec(
+		p.ctx,
+		`INSERT INTO wb_incomes (income_id, barcode, number, date, last_change_date, supplier_article, tech_size,
+			nm_id, quantity, total_price, date_close, warehouse_name, status, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			ON CONFLICT (seller_id, income_id, barcode) DO UPDATE
+				SET number = $3, date = $4, last_change_date = $5, supplier_article = $6, tech_size = $7,
+					nm_id = $8, quantity = $9, total_price = $10, date_close = $11, warehouse_name = $12,
+					status = $13, updated_timestamp = $14`,
+		income.IncomeID, income.Barcode, income.Number, income.Date, income.LastChangeDate,
+		income.SupplierArticle, income.TechSize, income.NmID, income.Quantity, income.TotalPrice,
+		nullIfZeroDate(income.DateClose), income.WarehouseName, income.Status,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи поставки %d (баркод %s) в базу данных возникла ошибка %s", income.IncomeID, income.Barcode, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// syncStatisticsReportDetail записывает детализацию отчетов о реализации полученную с api в БД
+func (p *pClinet) syncStatisticsReportDetail(rows []*wbapi.StatisticsReportDetail) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, row := range rows {
+		if err := p.upsertStatisticsReportDetail(tx, row); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Отчеты о реализации успешно синхронизировны"))
+
+	return nil
+}
+
+// upsertStatisticsReportDetail добавляет или обновляет строку отчета о реализации в БД
+func (p *pClinet) upsertStatisticsReportDetail(tx pgx.Tx, row *wbapi.StatisticsReportDetail) error {
+	_, err := tx.Exec(
+		p.ctx,
+		`INSERT INTO wb_realization_report (
+			rrd_id, realizationreport_id, date_from, date_to, create_dt, currency_name, gi_id, subject_name,
+			nm_id, brand_name, sa_name, ts_name, barcode, doc_type_name, quantity, retail_price,
+			retail_amount, sale_percent, commission_percent, office_name, supplier_oper_name, order_dt,
+			sale_dt, rr_dt, shk_id, retail_price_withdisc_rub, delivery_amount, return_amount, delivery_rub,
+			gi_box_type_name, product_discount_for_report, supplier_promo, ppvz_spp_prc, ppvz_kvw_prc_base,
+			ppvz_kvw_prc, ppvz_sales_commission, ppvz_for_pay, ppvz_reward, acquiring_fee, ppvz_vw,
+			ppvz_vw_nds, ppvz_office_id, bonus_type_name, sticker_id, site_country, penalty,
+			additional_payment, rebill_logistic_cost, storage_fee, deduction, acceptance, srid,
+			updated_timestamp, seller_id)
+			VALUES (
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21,
+				$22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37, $38, $39, $40,
+				$41, $42, $43, $44, $45, $46, $47, $48, $49, $50, $51, $52, $53, $54)
+			ON CONFLICT (seller_id, rrd_id) DO UPDATE
+				SET realizationreport_id = $2, date_from = $3, date_to = $4, create_dt = $5, currency_name = $6,
+					gi_id = $7, subject_name = $8, nm_id = $9, brand_name = $10, sa_name = $11, ts_name = $12,
+					barcode = $13, doc_type_name = $14, quantity = $15, retail_price = $16, retail_amount = $17,
+					sale_percent = $18, commission_percent = $19, office_name = $20, supplier_oper_name = $21,
+					order_dt = $22, sale_dt = $23, rr_dt = $24, shk_id = $25, retail_price_withdisc_rub = $26,
+					delivery_amount = $27, return_amount = $28, delivery_rub = $29, gi_box_type_name = $30,
+					product_discount_for_report = $31, supplier_promo = $32, ppvz_spp_prc = $33,
+					ppvz_kvw_prc_base = $34, ppvz_kvw_prc = $35, ppvz_sales_commission = $36, ppvz_for_pay = $37,
+					ppvz_reward = $38, acquiring_fee = $39, ppvz_vw = $40, ppvz_vw_nds = $41, ppvz_office_id = $42,
+					bonus_type_name = $43, sticker_id = $44, site_country = $45, penalty = $46,
+					additional_payment = $47, rebill_logistic_cost = $48, storage_fee = $49, deduction = $50,
+					acceptance = $51, srid = $52, updated_timestamp = $53`,
+		row.RrdID, row.RealizationReportID, nullIfZeroDate(row.DateFrom), nullIfZeroDate(row.DateTo),
+		nullIfZeroDate(row.CreateDt), row.CurrencyName, row.GiID, row.SubjectName, row.NmID,
+		row.BrandName, row.SaName, row.TsName, row.Barcode, row.DocTypeName, row.Quantity,
+		row.RetailPrice, row.RetailAmount, row.SalePercent, row.CommissionPercent, row.OfficeName,
+		row.SupplierOperName, nullIfZeroDate(row.OrderDt), nullIfZeroDate(row.SaleDt),
+		nullIfZeroDate(row.RrDt), row.ShkID, row.RetailPriceWithdiscRub, row.DeliveryAmount,
+		row.ReturnAmount, row.DeliveryRub, row.GiBoxTypeName, row.ProductDiscountForReport,
+		row.SupplierPromo, row.PpvzSppPrc, row.PpvzKvwPrcBase, row.PpvzKvwPrc, row.PpvzSalesCommission,
+		row.PpvzForPay, row.PpvzReward, row.AcquiringFee, row.PpvzVw, row.PpvzVwNds, row.PpvzOfficeID,
+		row.BonusTypeName, row.StickerID, row.SiteCountry, row.Penalty, row.AdditionalPayment,
+		row.RebillLogisticCost, row.StorageFee, row.Deduction, row.Acceptance, row.Srid,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи строки %d отчета о реализации в базу данных возникла ошибка %s", row.RrdID, err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/jackc/pgx/v5"
+)
+
+// upsertSupply добавляет или обновляет запись поставки в БД
+func (p *pClinet) upsertSupply(supply *wbapi.Supply) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_supplies (supply_id, name, done, created_at, closed_at, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (seller_id, supply_id) DO UPDATE
+				SET name = $2, done = $3, created_at = $4, closed_at = $5, updated_timestamp = $6`,
+		supply.ID, supply.Name, supply.Done, nullIfEmpty(supply.CreatedAt), nullIfEmpty(supply.ClosedAt),
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи поставки %s в базу данных возникла ошибка %s", supply.ID, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// upsertSupplyOrder добавляет или обновляет запись сборочного задания поставки в БД.
+// Список обязательных метаданных приходит только для новых заданий, поэтому не перезаписывается пустым значением
+func (p *pClinet) upsertSupplyOrder(supplyID string, order *wbapi.Order) error {
+	var sku string
+	if len(order.Skus) > 0 {
+		sku = order.Skus[0]
+	}
+
+	_, err := p.pool.Exec(
+		p.ctx,
+		`INSERT INTO wb_supply_orders (order_id, supply_id, nm_id, sku, article, created_at, required_meta, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (seller_id, order_id) DO UPDATE
+				SET supply_id = $2, nm_id = $3, sku = $4, article = $5, created_at = $6,
+					required_meta = COALESCE($7, wb_supply_orders.required_meta), updated_timestamp = $8`,
+		order.ID, supplyID, order.NmID, sku, order.Article, nullIfEmpty(order.CreatedAt), order.RequiredMeta,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи сборочного задания %d в базу данных возникла ошибка %s", order.ID, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// getOpenSupplyID возвращает ID открытой поставки с указанным именем.
+// Если поставка не найдена, то возвращается пустая строка
+func (p *pClinet) getOpenSupplyID(name string) (string, error) {
+	var supplyID string
+
+	err := p.pool.QueryRow(
+		p.ctx,
+		"SELECT supply_id FROM wb_supplies WHERE done is false AND name = $1 AND seller_id = $2 ORDER BY created_at DESC LIMIT 1",
+		name, p.sellerID,
+	).Scan(&supplyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return supplyID, err
+}
+
+// deleteSupply удаляет запись поставки из БД
+func (p *pClinet) deleteSupply(supplyID string) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`DELETE FROM wb_supplies WHERE supply_id = $1 AND seller_id = $2`,
+		supplyID, p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении поставки %s возникла ошибка %s", supplyID, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// updateSupplyOrderSticker сохраняет данные стикера сборочного задания в БД
+func (p *pClinet) updateSupplyOrderSticker(sticker *wbapi.OrderSticker) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_supply_orders SET sticker_barcode = $2, sticker_part_a = $3, sticker_part_b = $4, updated_timestamp = $5
+			WHERE order_id = $1 AND seller_id = $6`,
+		sticker.OrderID, sticker.Barcode, sticker.PartA, sticker.PartB,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи стикера сборочного задания %d в базу данных возникла ошибка %s", sticker.OrderID, err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// supplyOrderMetaRow описывает обязательные метаданные сборочного задания поставки
+type supplyOrderMetaRow struct {
+	OrderID      uint64   `db:"order_id"`
+	NmID         uint32   `db:"nm_id"`
+	RequiredMeta []string `db:"required_meta"`
+	KizRequired  bool     `db:"kiz_required"`
+}
+
+// getSupplyOrdersRequiredMeta возвращает обязательные метаданные активных сборочных заданий поставки.
+// Признак kiz_required берется из карточки товара
+func (p *pClinet) getSupplyOrdersRequiredMeta(supplyID string) ([]*supplyOrderMetaRow, error) {
+	rows, err := p.pool.Query(
+		p.ctx, `
+		SELECT orders.order_id, orders.nm_id,
+			COALESCE(orders.required_meta, '{}') as required_meta,
+			COALESCE(cards.kiz_required, false) as kiz_required
+		FROM wb_supply_orders as orders LEFT JOIN wb_content_cards as cards
+			ON orders.nm_id = cards.nm_id AND cards.seller_id = orders.seller_id
+		WHERE orders.supply_id = $1 AND orders.seller_id = $2 AND orders.canceled is false`,
+		supplyID, p.sellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	orders, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[supplyOrderMetaRow])
+	if err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// markSupplyOrderCanceled помечает сборочное задание как отмененное
+func (p *pClinet) markSupplyOrderCanceled(orderID uint64) error {
+	_, err := p.pool.Exec(
+		p.ctx,
+		`UPDATE wb_supply_orders SET canceled = true, updated_timestamp = $2 WHERE order_id = $1 AND seller_id = $3`,
+		orderID, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При отмене сборочного задания %d в БД возникла ошибка %s", orderID, err.Error()))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/jackc/pgx/v5"
+)
+
+// replaceTariffs заменяет в БД снимок тарифов и комиссий за дату данными полученными с api
+func (p *pClinet) replaceTariffs(
+	date string, box []*wbapi.BoxTariff, pallet []*wbapi.PalletTariff, ret []*wbapi.ReturnTariff, commissions []*wbapi.Commission,
+) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	for _, table := range []string{"wb_tariffs_box", "wb_tariffs_pallet", "wb_tariffs_return", "wb_commissions"} {
+		_, err := tx.Exec(
+			p.ctx,
+			fmt.Sprintf("DELETE FROM %s WHERE date = $1 AND seller_id = $2", pgx.Identifier{table}.Sanitize()),
+			date, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При удалении данных из таблицы %s возникла ошибка %s", table, err.Error()))
+			return err
+		}
+	}
+
+	updated := time.Now().UTC().Format("2006-01-02 15:04:05")
+
+	for _, t := range box {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_tariffs_box (date, warehouse_name, geo_name, delivery_and_storage_expr, delivery_base,
+				delivery_liter, storage_base, storage_liter, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			date, t.WarehouseName, t.GeoName, t.BoxDeliveryAndStorageExpr.Value(), t.BoxDeliveryBase.Value(),
+			t.BoxDeliveryLiter.Value(), t.BoxStorageBase.Value(), t.BoxStorageLiter.Value(), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи тарифа для коробов склада %s возникла ошибка %s", t.WarehouseName, err.Error()))
+			return err
+		}
+	}
+
+	for _, t := range pallet {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_tariffs_pallet (date, warehouse_name, delivery_expr, delivery_base, delivery_liter,
+				storage_expr, storage_value_expr, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			date, t.WarehouseName, t.PalletDeliveryExpr.Value(), t.PalletDeliveryValueBase.Value(),
+			t.PalletDeliveryValueLiter.Value(), t.PalletStorageExpr.Value(), t.PalletStorageValueExpr.Value(), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи тарифа для монопаллет склада %s возникла ошибка %s", t.WarehouseName, err.Error()))
+			return err
+		}
+	}
+
+	for _, t := range ret {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_tariffs_return (date, warehouse_name, kgt_office_base, kgt_office_liter, kgt_return_expr,
+				srg_office_expr, srg_return_expr, sup_courier_base, sup_courier_liter, sup_office_base,
+				sup_office_liter, sup_return_expr, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+			date, t.WarehouseName, t.DeliveryDumpKgtOfficeBase.Value(), t.DeliveryDumpKgtOfficeLiter.Value(),
+			t.DeliveryDumpKgtReturnExpr.Value(), t.DeliveryDumpSrgOfficeExpr.Value(), t.DeliveryDumpSrgReturnExpr.Value(),
+			t.DeliveryDumpSupCourierBase.Value(), t.DeliveryDumpSupCourierLiter.Value(), t.DeliveryDumpSupOfficeBase.Value(),
+			t.DeliveryDumpSupOfficeLiter.Value(), t.DeliveryDumpSupReturnExpr.Value(), updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи тарифа на возврат склада %s возникла ошибка %s", t.WarehouseName, err.Error()))
+			return err
+		}
+	}
+
+	for _, c := range commissions {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_commissions (date, subject_id, subject_name, parent_id, parent_name, kgvp_marketplace,
+				kgvp_supplier, kgvp_supplier_express, paid_storage_kgvp, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			date, c.SubjectID, c.SubjectName, c.ParentID, c.ParentName, c.KgvpMarketplace, c.KgvpSupplier,
+			c.KgvpSupplierExpress, c.PaidStorageKgvp, updated, p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи комиссии предмета %d возникла ошибка %s", c.SubjectID, err.Error()))
+			return err
+		}
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Тарифы и комиссии на %s успешно синхронизировны", date))
+
+	return nil
+}
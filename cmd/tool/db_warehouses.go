@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/jackc/pgx/v5"
+)
+
+// syncOffices синхронизирует склады WB полученные с api в БД.
+// Склады, которых нет в ответе api, помечаются как удаленные
+func (p *pClinet) syncOffices(offices []*wbapi.Office) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	// Пустой, а не nil срез, чтобы при пустом ответе api все склады были помечены как удаленные
+	ids := make([]uint32, 0, len(offices))
+	for _, office := range offices {
+		_, err := tx.Exec(
+			p.ctx,
+			`INSERT INTO wb_offices (office_id, name, address, city, longitude, latitude, cargo_type, delivery_type, selected, deleted, updated_timestamp, seller_id)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, false, $10, $11)
+				ON CONFLICT (seller_id, office_id) DO UPDATE
+					SET name = $2, address = $3, city = $4, longitude = $5, latitude = $6,
+						cargo_type = $7, delivery_type = $8, selected = $9, deleted = false, updated_timestamp = $10`,
+			office.ID, office.Name, office.Address, office.City, office.Longitude, office.Latitude,
+			office.CargoType, office.DeliveryType, office.Selected,
+			time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+		)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При записи склада WB %d в базу данных возникла ошибка %s", office.ID, err.Error()))
+			return err
+		}
+		ids = append(ids, office.ID)
+	}
+
+	_, err = tx.Exec(
+		p.ctx,
+		`UPDATE wb_offices SET deleted = true, updated_timestamp = $2
+			WHERE NOT (office_id = ANY($1)) AND deleted is false AND seller_id = $3`,
+		ids, time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении складов WB возникла ошибка %s", err.Error()))
+		return err
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info("Склады WB успешно синхронизировны")
+
+	return nil
+}
+
+// syncWarehouses синхронизирует склады продавца полученные с api в БД
+func (p *pClinet) syncWarehouses(warehouses []*wbapi.Warehouse) error {
+	tx, err := p.pool.Begin(p.ctx)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При создании транзакции произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	defer tx.Rollback(p.ctx)
+
+	// Пустой, а не nil срез, чтобы при пустом ответе api все склады были удалены
+	ids := make([]uint32, 0, len(warehouses))
+	for _, warehouse := range warehouses {
+		if err := p.upsertWarehouse(tx, warehouse); err != nil {
+			return err
+		}
+		ids = append(ids, warehouse.ID)
+	}
+
+	_, err = tx.Exec(p.ctx, `DELETE FROM wb_warehouses WHERE NOT (warehouse_id = ANY($1)) AND seller_id = $2`, ids, p.sellerID)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При удалении складов продавца возникла ошибка %s", err.Error()))
+		return err
+	}
+
+	if err := tx.Commit(p.ctx); err != nil {
+		slog.Error(fmt.Sprintf("При коммите изменений в БД произошла ошибка %s", err.Error()))
+		return err
+	}
+	slog.Info("Склады продавца успешно синхронизировны")
+
+	return nil
+}
+
+// upsertWarehouse добавляет или обновляет запись склада продавца в БД
+func (p *pClinet) upsertWarehouse(tx pgx.Tx, warehouse *wbapi.Warehouse) error {
+	_, err := tx.Exec(
+		p.ctx,
+		`INSERT INTO wb_warehouses (warehouse_id, name, office_id, cargo_type, delivery_type, updated_timestamp, seller_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (seller_id, warehouse_id) DO UPDATE
+				SET name = $2, office_id = $3, cargo_type = $4, delivery_type = $5, updated_timestamp = $6`,
+		warehouse.ID, warehouse.Name, warehouse.OfficeID, warehouse.CargoType, warehouse.DeliveryType,
+		time.Now().UTC().Format("2006-01-02 15:04:05"), p.sellerID,
+	)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При записи склада продавца %d в базу данных возникла ошибка %s", warehouse.ID, err.Error()))
+		return err
+	}
+
+	return nil
+}
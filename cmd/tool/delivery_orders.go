@@ -0,0 +1,194 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// deliveryFlowNames названия схем работы для логов и уведомлений
+var deliveryFlowNames = map[wbapi.DeliveryFlow]string{
+	wbapi.DeliveryFlowDBS:     "DBS",
+	wbapi.DeliveryFlowExpress: "самовывоза",
+}
+
+// syncDeliveryOrdersFromAPI сохраняет новые сборочные задания схемы flow в БД и обновляет статусы незавершенных.
+// Возвращает задания, которых раньше не было в БД
+func syncDeliveryOrdersFromAPI(s *seller, flow wbapi.DeliveryFlow) ([]*wbapi.DeliveryOrder, error) {
+	orders, err := s.client.GetNewDeliveryOrders(flow)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении новых сборочных заданий %s произошла ошибка %s", deliveryFlowNames[flow], err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d новых сборочных заданий %s", len(orders), deliveryFlowNames[flow]))
+
+	added, err := s.db.addDeliveryOrders(flow, orders)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации сборочных заданий %s произошла ошибка %s", deliveryFlowNames[flow], err.Error()))
+		return nil, err
+	}
+
+	if err := syncDeliveryOrderStatuses(s, flow); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// syncDeliveryOrderStatuses обновляет в БД статусы незавершенных сборочных заданий схемы flow
+func syncDeliveryOrderStatuses(s *seller, flow wbapi.DeliveryFlow, orderIDs ...uint64) error {
+	if len(orderIDs) == 0 {
+		ids, err := s.db.getOpenDeliveryOrderIDs(flow)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При получении незавершенных сборочных заданий произошла ошибка %s", err.Error()))
+			return err
+		}
+		orderIDs = ids
+	}
+
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	statuses, err := s.client.GetDeliveryOrderStatuses(flow, orderIDs)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении статусов сборочных заданий произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	return s.db.updateDeliveryOrderStatuses(statuses)
+}
+
+// deliveryOrdersSync синхронизирует сборочные задания DBS и самовывоза и уведомляет о новых заданиях
+func deliveryOrdersSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	for _, flow := range []wbapi.DeliveryFlow{wbapi.DeliveryFlowDBS, wbapi.DeliveryFlowExpress} {
+		added, err := syncDeliveryOrdersFromAPI(s, flow)
+		if err != nil || len(added) == 0 {
+			continue
+		}
+
+		var lines []string
+		for _, o := range added {
+			line := fmt.Sprintf("%d: карточка %d (%s)", o.ID, o.NmID, o.Article)
+			if o.DDate != "" {
+				line = fmt.Sprintf("%s, доставка %s %s-%s", line, o.DDate, o.DTimeFrom, o.DTimeTo)
+			}
+			lines = append(lines, line)
+		}
+		s.notify(fmt.Sprintf("Новые сборочные задания %s (%d):\n%s", deliveryFlowNames[flow], len(added), strings.Join(lines, "\n")))
+	}
+}
+
+// deliveryOrdersList синхронизирует и выводит незавершенные сборочные задания схемы flow
+func deliveryOrdersList(s *seller, flow wbapi.DeliveryFlow) error {
+	if _, err := syncDeliveryOrdersFromAPI(s, flow); err != nil {
+		return err
+	}
+
+	orders, err := s.db.getOpenDeliveryOrders(flow)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range orders {
+		var wbStatus, deliveryDate, address string
+		if o.wbStatus != nil {
+			wbStatus = *o.wbStatus
+		}
+		if o.deliveryDate != nil {
+			deliveryDate = *o.deliveryDate
+		}
+		if o.address != nil {
+			address = *o.address
+		}
+
+		fmt.Printf(
+			"%d\t%d\t%s\t%.2f\t%s\t%s\t%s\t%s\n",
+			o.orderID, o.nmID, o.article, float64(o.price)/100, o.supplierStatus, wbStatus, deliveryDate, address,
+		)
+	}
+
+	return nil
+}
+
+// deliveryOrderAction выполняет действие со сборочным заданием схемы flow и обновляет его статус в БД.
+// Действие client выводит информацию о покупателе
+func deliveryOrderAction(s *seller, flow wbapi.DeliveryFlow, args []string) error {
+	if len(args) < 2 {
+		return errors.New("не указано действие или ID сборочного задания")
+	}
+
+	action := args[0]
+
+	orderID, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("неверный ID сборочного задания %s", args[1])
+	}
+
+	var code string
+	if action == wbapi.DeliveryStatusReceive || action == wbapi.DeliveryStatusReject {
+		if len(args) < 3 {
+			return errors.New("не указан код подтверждения покупателя")
+		}
+		code = args[2]
+	}
+
+	switch {
+	case action == "client":
+		clients, err := s.client.GetDeliveryClients(flow, []uint64{orderID})
+		if err != nil {
+			return err
+		}
+		for _, c := range clients {
+			fmt.Printf("%d\t%s\t%s\t%s\n", c.OrderID, c.FullName, c.Phone, c.ReplacementPhone)
+		}
+		return nil
+	case action == wbapi.DeliveryStatusConfirm:
+		err = s.client.ConfirmDeliveryOrder(flow, orderID)
+	case action == wbapi.DeliveryStatusDeliver && flow == wbapi.DeliveryFlowDBS:
+		err = s.client.DeliverDeliveryOrder(orderID)
+	case action == wbapi.DeliveryStatusPrepare && flow == wbapi.DeliveryFlowExpress:
+		err = s.client.PrepareDeliveryOrder(orderID)
+	case action == wbapi.DeliveryStatusReceive:
+		err = s.client.ReceiveDeliveryOrder(flow, orderID, code)
+	case action == wbapi.DeliveryStatusReject:
+		err = s.client.RejectDeliveryOrder(flow, orderID, code)
+	case action == wbapi.DeliveryStatusCancel:
+		err = s.client.CancelDeliveryOrder(flow, orderID)
+	default:
+		return fmt.Errorf("неизвестное действие %s для сборочного задания %s", action, deliveryFlowNames[flow])
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Сборочное задание %d переведено в статус %s", orderID, action))
+
+	return syncDeliveryOrderStatuses(s, flow, orderID)
+}
+
+// dbsOrdersCommand синхронизирует и выводит незавершенные сборочные задания DBS
+func dbsOrdersCommand(s *seller, args []string) error {
+	return deliveryOrdersList(s, wbapi.DeliveryFlowDBS)
+}
+
+// dbsOrderCommand выполняет действие со сборочным заданием DBS
+func dbsOrderCommand(s *seller, args []string) error {
+	return deliveryOrderAction(s, wbapi.DeliveryFlowDBS, args)
+}
+
+// expressOrdersCommand синхронизирует и выводит незавершенные сборочные задания самовывоза
+func expressOrdersCommand(s *seller, args []string) error {
+	return deliveryOrdersList(s, wbapi.DeliveryFlowExpress)
+}
+
+// expressOrderCommand выполняет действие со сборочным заданием самовывоза
+func expressOrderCommand(s *seller, args []string) error {
+	return deliveryOrderAction(s, wbapi.DeliveryFlowExpress, args)
+}
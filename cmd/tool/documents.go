@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// syncDocumentsArchive синхронизирует документы за период с БД и сохраняет в архив документы
+// в форматах из настройки documents.extensions. Документы хранятся в <documents.dir>/<категория>/<документ>_<файл>,
+// если кабинетов несколько, то в <documents.dir>/<кабинет>/<категория>/<документ>_<файл>.
+// Ошибка загрузки документа записывается в БД, остальные документы продолжают загружаться
+func syncDocumentsArchive(s *seller, beginTime string, endTime string) ([]*wbapi.Document, error) {
+	documents, err := s.client.GetDocuments(beginTime, endTime, "")
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении документов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d документов с %s по %s", len(documents), beginTime, endTime))
+
+	if err := s.db.syncDocuments(documents); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации документов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	var extensions []string
+	for _, extension := range strings.Split(config.GetString("documents.extensions"), ",") {
+		extensions = append(extensions, strings.TrimSpace(extension))
+	}
+
+	files, err := s.db.getDocumentsToDownload(extensions, downloadMaxAttempts)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении документов из БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	var saved int
+	for _, d := range files {
+		file, err := s.client.DownloadDocument(d.serviceName, d.extension)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При загрузке документа %s произошла ошибка %s", d.serviceName, err.Error()))
+			if err := s.db.setDocumentError(d, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Имя файла может совпадать у разных документов, поэтому в начало добавляется serviceName документа
+		name := filepath.Base(file.FileName)
+		if file.FileName == "" || name == "." || name == string(filepath.Separator) {
+			name = fmt.Sprintf("%s.%s", filepath.Base(d.serviceName), d.extension)
+		} else {
+			name = fmt.Sprintf("%s_%s", filepath.Base(d.serviceName), name)
+		}
+		path := filepath.Join(s.dir(config.GetString("documents.dir")), filepath.Base(d.category), name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, file.Data, 0644); err != nil {
+			return nil, err
+		}
+
+		if err := s.db.setDocumentPath(d, path); err != nil {
+			return nil, err
+		}
+		saved++
+	}
+	slog.Info(fmt.Sprintf("В архив сохранено %d документов из %d", saved, len(files)))
+
+	return documents, nil
+}
+
+// documentsSync сохраняет в архив документы за последние дни, заданные настройкой documents.sync_days
+func documentsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	beginTime := time.Now().AddDate(0, 0, -config.GetInt("documents.sync_days")).Format("2006-01-02")
+	endTime := time.Now().Format("2006-01-02")
+
+	if _, err := syncDocumentsArchive(s, beginTime, endTime); err != nil {
+		return
+	}
+}
+
+// documentsCommand сохраняет в архив и выводит документы за период
+func documentsCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("documents", flag.ContinueOnError)
+	beginTime := flags.String(
+		"from", time.Now().AddDate(0, 0, -config.GetInt("documents.sync_days")).Format("2006-01-02"), "Начало периода",
+	)
+	endTime := flags.String("to", time.Now().Format("2006-01-02"), "Конец периода")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	documents, err := syncDocumentsArchive(s, *beginTime, *endTime)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range documents {
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\n", d.CreationTime, d.Category, d.ServiceName, strings.Join(d.Extensions, ","), d.Name)
+	}
+
+	return nil
+}
+
+// documentCategoriesCommand выводит категории документов
+func documentCategoriesCommand(s *seller, args []string) error {
+	categories, err := s.client.GetDocumentCategories()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		fmt.Printf("%s\t%s\n", c.Name, c.Title)
+	}
+
+	return nil
+}
+
+// documentDownloadCommand сохраняет документ в файл
+func documentDownloadCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("document-download", flag.ContinueOnError)
+	extension := flags.String("ext", "pdf", "Формат документа")
+	out := flags.String("out", "", "Файл для сохранения. По умолчанию имя документа")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("не указан serviceName документа")
+	}
+
+	file, err := s.client.DownloadDocument(flags.Arg(0), *extension)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		*out = filepath.Base(file.FileName)
+	}
+
+	if err := os.WriteFile(*out, file.Data, 0644); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Документ сохранен в %s", *out))
+
+	return nil
+}
+
+// balanceSync сохраняет баланс продавца за текущий день
+func balanceSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	balance, err := s.client.GetBalance()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении баланса продавца произошла ошибка %s", err.Error()))
+		return
+	}
+
+	if err := s.db.upsertBalance(balance); err != nil {
+		return
+	}
+}
+
+// balanceCommand выводит и сохраняет баланс продавца
+func balanceCommand(s *seller, args []string) error {
+	balance, err := s.client.GetBalance()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Баланс\t%.2f %s\n", balance.Current, balance.Currency)
+	fmt.Printf("Доступно к выводу\t%.2f %s\n", balance.ForWithdraw, balance.Currency)
+
+	return s.db.upsertBalance(balance)
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// acceptanceWatchConfig описывает склад и тип упаковки, за слотами приемки которых нужно следить.
+// Если тип упаковки не указан, то отслеживаются все типы
+type acceptanceWatchConfig struct {
+	WarehouseID    uint32  `mapstructure:"warehouse_id"`
+	BoxTypeID      uint8   `mapstructure:"box_type_id"`
+	MaxCoefficient float64 `mapstructure:"max_coefficient"`
+}
+
+// parseAcceptanceGoods разбирает список товаров в формате <баркод>=<количество>.
+// Вместо = можно использовать ; , табуляцию или пробел, пустые строки и строки с # пропускаются.
+// Метка порядка байтов, которую добавляют некоторые редакторы в начало файла, удаляется
+func parseAcceptanceGoods(lines []string) ([]*wbapi.AcceptanceGood, error) {
+	var goods []*wbapi.AcceptanceGood
+
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool { return strings.ContainsRune("=;,\t ", r) })
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("неверный формат товара %q, ожидается <баркод>=<количество>", line)
+		}
+
+		quantity, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil || quantity == 0 {
+			return nil, fmt.Errorf("неверное количество товара %q", line)
+		}
+
+		goods = append(goods, &wbapi.AcceptanceGood{Barcode: fields[0], Quantity: uint32(quantity)})
+	}
+
+	return goods, nil
+}
+
+// acceptancePlanCommand выводит склады WB, которые принимают указанные товары,
+// и коэффициенты приемки на ближайшие 14 дней, начиная с самых ранних дат и низких коэффициентов
+func acceptancePlanCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("acceptance-plan", flag.ContinueOnError)
+	file := flags.String("file", "", "Файл со списком товаров, по одному <баркод>=<количество> в строке")
+	maxCoefficient := flags.Float64("max", -1, "Максимальный коэффициент приемки. По умолчанию любой")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	lines := flags.Args()
+	if *file != "" {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, strings.Split(string(data), "\n")...)
+	}
+
+	goods, err := parseAcceptanceGoods(lines)
+	if err != nil {
+		return err
+	}
+	if len(goods) == 0 {
+		return errors.New("не указаны товары")
+	}
+
+	options, err := s.client.GetAcceptanceOptions(goods)
+	if err != nil {
+		return err
+	}
+
+	// Баркоды, которые склад принимает, по складам и типам упаковки
+	accepted := make(map[uint32]map[uint8][]string)
+	for _, option := range options {
+		if option.IsError {
+			detail := ""
+			if option.Error != nil {
+				detail = option.Error.Detail
+			}
+			slog.Warn(fmt.Sprintf("Товар %s не может быть принят: %s", option.Barcode, detail))
+			continue
+		}
+
+		for _, w := range option.Warehouses {
+			if _, ok := accepted[w.WarehouseID]; !ok {
+				accepted[w.WarehouseID] = make(map[uint8][]string)
+			}
+			for _, boxType := range []uint8{wbapi.BoxTypeBox, wbapi.BoxTypeMonopallet, wbapi.BoxTypeSupersafe} {
+				if w.Can(boxType) {
+					accepted[w.WarehouseID][boxType] = append(accepted[w.WarehouseID][boxType], option.Barcode)
+				}
+			}
+		}
+	}
+
+	if len(accepted) == 0 {
+		return errors.New("ни один склад не принимает указанные товары")
+	}
+
+	var warehouseIDs []uint32
+	for id := range accepted {
+		warehouseIDs = append(warehouseIDs, id)
+	}
+
+	coefficients, err := s.client.GetAcceptanceCoefficients(warehouseIDs)
+	if err != nil {
+		return err
+	}
+
+	var available []*wbapi.AcceptanceCoefficient
+	for _, c := range coefficients {
+		if !c.Available() || (*maxCoefficient >= 0 && c.Coefficient > *maxCoefficient) {
+			continue
+		}
+		if len(accepted[c.WarehouseID][c.BoxTypeID]) == 0 {
+			continue
+		}
+		available = append(available, c)
+	}
+
+	sort.Slice(available, func(i, j int) bool {
+		if available[i].Date != available[j].Date {
+			return available[i].Date < available[j].Date
+		}
+		return available[i].Coefficient < available[j].Coefficient
+	})
+
+	for _, c := range available {
+		fmt.Printf(
+			"%s\t%d\t%s\t%s\t%g\t%d/%d\n",
+			c.Date[:min(len(c.Date), 10)], c.WarehouseID, c.WarehouseName, c.BoxTypeName, c.Coefficient,
+			len(accepted[c.WarehouseID][c.BoxTypeID]), len(goods),
+		)
+	}
+
+	return nil
+}
+
+// fbwWarehousesCommand выводит список складов WB для поставок FBW
+func fbwWarehousesCommand(s *seller, args []string) error {
+	warehouses, err := s.client.GetSuppliesWarehouses()
+	if err != nil {
+		return err
+	}
+
+	for _, w := range warehouses {
+		fmt.Printf("%d\t%s\t%s\t%s\n", w.ID, w.Name, w.Address, w.WorkTime)
+	}
+
+	return nil
+}
+
+// getAcceptanceWatchConfig возвращает отслеживаемые склады из настройки supplies.acceptance_watch
+func getAcceptanceWatchConfig() ([]*acceptanceWatchConfig, error) {
+	var watches []*acceptanceWatchConfig
+	if err := config.UnmarshalKey("supplies.acceptance_watch", &watches); err != nil {
+		return nil, err
+	}
+
+	for _, w := range watches {
+		if w.WarehouseID == 0 {
+			return nil, errors.New("в настройке supplies.acceptance_watch не указан warehouse_id")
+		}
+	}
+
+	return watches, nil
+}
+
+// acceptanceWatch проверяет коэффициенты приемки отслеживаемых складов и отправляет уведомление,
+// когда появляется доступный слот с коэффициентом не выше заданного. Об одном слоте уведомление
+// отправляется повторно, только если он пропадал
+func acceptanceWatch(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	watches, err := getAcceptanceWatchConfig()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При чтении отслеживаемых складов произошла ошибка %s", err.Error()))
+		return
+	}
+	if len(watches) == 0 {
+		slog.Warn("Отслеживаемые склады не заданы")
+		return
+	}
+
+	var warehouseIDs []uint32
+	for _, w := range watches {
+		warehouseIDs = append(warehouseIDs, w.WarehouseID)
+	}
+
+	coefficients, err := s.client.GetAcceptanceCoefficients(warehouseIDs)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении коэффициентов приемки произошла ошибка %s", err.Error()))
+		return
+	}
+
+	matchedBefore, err := s.db.getMatchedAcceptanceSlots()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении слотов приемки из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	for _, c := range coefficients {
+		var watched, matched bool
+		for _, w := range watches {
+			if w.WarehouseID != c.WarehouseID || (w.BoxTypeID != 0 && w.BoxTypeID != c.BoxTypeID) {
+				continue
+			}
+			watched = true
+			if c.Available() && c.Coefficient <= w.MaxCoefficient {
+				matched = true
+			}
+		}
+		if !watched {
+			continue
+		}
+
+		key := acceptanceSlotKey{warehouseID: c.WarehouseID, boxTypeID: c.BoxTypeID, date: c.Date[:min(len(c.Date), 10)]}
+		notified := matched && !matchedBefore[key]
+		if notified {
+			s.notify(fmt.Sprintf(
+				"Доступна приемка на складе %s (%d), %s, %s, коэффициент %g",
+				c.WarehouseName, c.WarehouseID, c.BoxTypeName, key.date, c.Coefficient,
+			))
+		}
+
+		if err := s.db.upsertAcceptanceSlot(c, matched, notified); err != nil {
+			return
+		}
+	}
+
+	if err := s.db.deleteExpiredAcceptanceSlots(); err != nil {
+		return
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+func TestParseAcceptanceGoods(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []wbapi.AcceptanceGood
+		err   bool
+	}{
+		{name: "равно", lines: []string{"4600000000001=5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "точка с запятой", lines: []string{"4600000000001;5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "запятая", lines: []string{"4600000000001,5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "табуляция", lines: []string{"4600000000001\t5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "пробелы", lines: []string{"  4600000000001   5  "}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "пробелы вокруг разделителя", lines: []string{"4600000000001 = 5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "перевод строки windows", lines: []string{"4600000000001=5\r"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{name: "BOM в начале файла", lines: []string{"\ufeff4600000000001=5"}, want: []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}}},
+		{
+			name:  "несколько строк с комментариями",
+			lines: []string{"# товары", "", "4600000000001=5", "   ", "4600000000002;10"},
+			want:  []wbapi.AcceptanceGood{{Barcode: "4600000000001", Quantity: 5}, {Barcode: "4600000000002", Quantity: 10}},
+		},
+		{name: "пустой список", lines: nil, want: nil},
+		{name: "нулевое количество", lines: []string{"4600000000001=0"}, err: true},
+		{name: "количество прочерк", lines: []string{"4600000000001=-"}, err: true},
+		{name: "отрицательное количество", lines: []string{"4600000000001=-5"}, err: true},
+		{name: "дробное количество через точку", lines: []string{"4600000000001=1.5"}, err: true},
+		{name: "дробное количество через запятую", lines: []string{"4600000000001=1,5"}, err: true},
+		{name: "слишком большое количество", lines: []string{"4600000000001=4294967296"}, err: true},
+		{name: "нет количества", lines: []string{"4600000000001"}, err: true},
+		{name: "нет баркода", lines: []string{"=5"}, err: true},
+		{name: "лишнее поле", lines: []string{"4600000000001=5=6"}, err: true},
+		{name: "ошибка после верной строки", lines: []string{"4600000000001=5", "4600000000002=x"}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goods, err := parseAcceptanceGoods(tt.lines)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("ожидалась ошибка, получено %v", goods)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(goods) != len(tt.want) {
+				t.Fatalf("получено %d товаров, ожидалось %d", len(goods), len(tt.want))
+			}
+			for i, g := range goods {
+				if *g != tt.want[i] {
+					t.Errorf("товар %d: получено %+v, ожидалось %+v", i, *g, tt.want[i])
+				}
+			}
+		})
+	}
+}
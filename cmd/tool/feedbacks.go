@@ -0,0 +1,244 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// syncFeedbacksAndQuestions загружает в БД все необработанные отзывы и вопросы,
+// а также обработанные за последние дни, заданные настройкой feedbacks.sync_days.
+// Необработанные в БД отзывы и вопросы, которых нет в полном списке необработанных, отмечаются обработанными
+func syncFeedbacksAndQuestions(s *seller) error {
+	dateFrom := time.Now().AddDate(0, 0, -config.GetInt("feedbacks.sync_days"))
+
+	var feedbacks []*wbapi.Feedback
+	var unansweredFeedbackIDs []string
+	for _, isAnswered := range []bool{false, true} {
+		var from time.Time
+		if isAnswered {
+			from = dateFrom
+		}
+
+		f, err := s.client.GetFeedbacks(isAnswered, from)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При получении отзывов произошла ошибка %s", err.Error()))
+			return err
+		}
+		feedbacks = append(feedbacks, f...)
+
+		if !isAnswered {
+			for _, feedback := range f {
+				unansweredFeedbackIDs = append(unansweredFeedbackIDs, feedback.ID)
+			}
+		}
+	}
+	slog.Info(fmt.Sprintf("Получено %d отзывов", len(feedbacks)))
+
+	if err := s.db.syncFeedbacks(feedbacks); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации отзывов произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	if err := s.db.markMissingFeedbacksAnswered(unansweredFeedbackIDs); err != nil {
+		return err
+	}
+
+	var questions []*wbapi.Question
+	var unansweredQuestionIDs []string
+	for _, isAnswered := range []bool{false, true} {
+		var from time.Time
+		if isAnswered {
+			from = dateFrom
+		}
+
+		q, err := s.client.GetQuestions(isAnswered, from)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При получении вопросов произошла ошибка %s", err.Error()))
+			return err
+		}
+		questions = append(questions, q...)
+
+		if !isAnswered {
+			for _, question := range q {
+				unansweredQuestionIDs = append(unansweredQuestionIDs, question.ID)
+			}
+		}
+	}
+	slog.Info(fmt.Sprintf("Получено %d вопросов", len(questions)))
+
+	if err := s.db.syncQuestions(questions); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации вопросов произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	if err := s.db.markMissingQuestionsAnswered(unansweredQuestionIDs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// feedbacksSync синхронизирует отзывы и вопросы
+func feedbacksSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if err := syncFeedbacksAndQuestions(s); err != nil {
+		return
+	}
+}
+
+// feedbacksCommand синхронизирует отзывы и вопросы и выводит необработанные, начиная с самых старых
+func feedbacksCommand(s *seller, args []string) error {
+	if err := syncFeedbacksAndQuestions(s); err != nil {
+		return err
+	}
+
+	unanswered, err := s.db.getUnansweredFeedbacks()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range unanswered {
+		rating := "-"
+		if f.valuation != nil {
+			rating = fmt.Sprintf("%d", *f.valuation)
+		}
+		fmt.Printf(
+			"%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			f.kind, f.id, f.nmID, f.supplierArticle, rating, f.waiting.Round(time.Minute), shortText(f.text, 60),
+		)
+	}
+
+	return nil
+}
+
+// shortText возвращает текст в одну строку, обрезанный до max символов
+func shortText(text string, max int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) <= max {
+		return string(runes)
+	}
+
+	return string(runes[:max]) + "…"
+}
+
+// parseAnswerArgs возвращает идентификатор и текст ответа из аргументов команды
+func parseAnswerArgs(args []string) (string, string, error) {
+	if len(args) < 2 {
+		return "", "", errors.New("не указан идентификатор или текст ответа")
+	}
+
+	text := strings.TrimSpace(strings.Join(args[1:], " "))
+	if text == "" {
+		return "", "", errors.New("текст ответа пустой")
+	}
+
+	return args[0], text, nil
+}
+
+// feedbackAnswerCommand отвечает на отзыв или изменяет ответ
+func feedbackAnswerCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("feedback-answer", flag.ContinueOnError)
+	edit := flags.Bool("edit", false, "Изменить существующий ответ")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	id, text, err := parseAnswerArgs(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if *edit {
+		err = s.client.EditFeedbackAnswer(id, text)
+	} else {
+		err = s.client.AnswerFeedback(id, text)
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Ответ на отзыв %s отправлен", id))
+
+	return s.db.markFeedbackAnswered(id, text)
+}
+
+// questionAnswerCommand отвечает на вопрос или изменяет ответ
+func questionAnswerCommand(s *seller, args []string) error {
+	id, text, err := parseAnswerArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.AnswerQuestion(id, text); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Ответ на вопрос %s отправлен", id))
+
+	return s.db.markQuestionAnswered(id, text)
+}
+
+// feedbackViewedCommand отмечает отзывы просмотренными
+func feedbackViewedCommand(s *seller, args []string) error {
+	if len(args) == 0 {
+		return errors.New("не указан идентификатор отзыва")
+	}
+
+	for _, id := range args {
+		if err := s.client.MarkFeedbackViewed(id); err != nil {
+			return err
+		}
+		slog.Info(fmt.Sprintf("Отзыв %s отмечен просмотренным", id))
+
+		if err := s.db.markFeedbackViewed(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// questionViewedCommand отмечает вопросы просмотренными
+func questionViewedCommand(s *seller, args []string) error {
+	if len(args) == 0 {
+		return errors.New("не указан идентификатор вопроса")
+	}
+
+	for _, id := range args {
+		if err := s.client.MarkQuestionViewed(id); err != nil {
+			return err
+		}
+		slog.Info(fmt.Sprintf("Вопрос %s отмечен просмотренным", id))
+
+		if err := s.db.markQuestionViewed(id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// feedbacksCountCommand выводит количество необработанных отзывов и вопросов и среднюю оценку товаров
+func feedbacksCountCommand(s *seller, args []string) error {
+	feedbacks, err := s.client.GetFeedbacksCountUnanswered()
+	if err != nil {
+		return err
+	}
+
+	questions, err := s.client.GetQuestionsCountUnanswered()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Необработанные отзывы\t%d (сегодня %d)\n", feedbacks.CountUnanswered, feedbacks.CountUnansweredToday)
+	fmt.Printf("Необработанные вопросы\t%d (сегодня %d)\n", questions.CountUnanswered, questions.CountUnansweredToday)
+	fmt.Printf("Средняя оценка\t%s\n", feedbacks.Valuation)
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+const (
+	autoReplyVarBuyerName    string = "{buyer_name}"
+	autoReplyVarProductTitle string = "{product_title}"
+)
+
+// autoReplyRule описывает правило автоответа на отзывы в файле настроек.
+// Пустые условия не ограничивают выбор отзывов, правила проверяются по порядку
+type autoReplyRule struct {
+	Name            string   `mapstructure:"name"`
+	Ratings         []uint8  `mapstructure:"ratings"`
+	Subjects        []string `mapstructure:"subjects"`
+	Brands          []string `mapstructure:"brands"`
+	Keywords        []string `mapstructure:"keywords"`
+	ExcludeKeywords []string `mapstructure:"exclude_keywords"`
+	Templates       []string `mapstructure:"templates"`
+	DailyLimit      int      `mapstructure:"daily_limit"`
+}
+
+// getAutoReplyRules возвращает правила автоответа из настройки feedbacks.auto_reply_rules
+func getAutoReplyRules() ([]*autoReplyRule, error) {
+	var rules []*autoReplyRule
+	if err := config.UnmarshalKey("feedbacks.auto_reply_rules", &rules); err != nil {
+		return nil, err
+	}
+
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("не указано имя правила автоответа %d", i+1)
+		}
+		if len(rule.Templates) == 0 {
+			return nil, fmt.Errorf("в правиле автоответа %s нет шаблонов", rule.Name)
+		}
+	}
+
+	return rules, nil
+}
+
+// match проверяет, подходит ли отзыв под условия правила
+func (r *autoReplyRule) match(f *feedbackForReply) bool {
+	if len(r.Ratings) > 0 && !slices.Contains(r.Ratings, f.valuation) {
+		return false
+	}
+	if len(r.Subjects) > 0 && !containsFold(r.Subjects, f.subjectName) {
+		return false
+	}
+	if len(r.Brands) > 0 && !containsFold(r.Brands, f.brand) {
+		return false
+	}
+
+	text := strings.ToLower(f.text)
+	if len(r.Keywords) > 0 && !slices.ContainsFunc(r.Keywords, func(k string) bool {
+		return strings.Contains(text, strings.ToLower(k))
+	}) {
+		return false
+	}
+	if slices.ContainsFunc(r.ExcludeKeywords, func(k string) bool {
+		return strings.Contains(text, strings.ToLower(k))
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// render возвращает текст ответа по шаблону правила.
+// Шаблон выбирается по идентификатору отзыва, чтобы ответы не повторялись подряд.
+// Шаблоны с именем покупателя пропускаются, если имя не указано
+func (r *autoReplyRule) render(f *feedbackForReply) (string, bool) {
+	var templates []string
+	for _, t := range r.Templates {
+		if f.userName == "" && strings.Contains(t, autoReplyVarBuyerName) {
+			continue
+		}
+		if f.productTitle == "" && strings.Contains(t, autoReplyVarProductTitle) {
+			continue
+		}
+		templates = append(templates, t)
+	}
+	if len(templates) == 0 {
+		return "", false
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(f.id))
+	template := templates[h.Sum32()%uint32(len(templates))]
+
+	replacer := strings.NewReplacer(autoReplyVarBuyerName, f.userName, autoReplyVarProductTitle, f.productTitle)
+
+	return strings.TrimSpace(replacer.Replace(template)), true
+}
+
+// containsFold проверяет, есть ли значение в списке без учета регистра
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
+}
+
+// autoReply описывает подготовленный автоответ на отзыв
+type autoReply struct {
+	feedback *feedbackForReply
+	rule     string
+	text     string
+}
+
+// autoReplyFeedbacks отвечает на необработанные отзывы по правилам из настроек и возвращает подготовленные ответы.
+// В режиме dryRun ответы не отправляются, а только записываются в журнал wb_feedback_auto_replies.
+// Отвечать можно только на отзывы из списка необработанных с api, для которых в журнале еще нет автоответа.
+// Количество ответов за день ограничено настройкой feedbacks.auto_reply_daily_limit и лимитом правила
+func autoReplyFeedbacks(s *seller, dryRun bool) ([]*autoReply, error) {
+	rules, err := getAutoReplyRules()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При чтении правил автоответа произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	if len(rules) == 0 {
+		slog.Warn("Правила автоответа на отзывы не заданы")
+		return nil, nil
+	}
+
+	feedbacks, err := s.client.GetFeedbacks(false, time.Time{})
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении отзывов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	if err := s.db.syncFeedbacks(feedbacks); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации отзывов произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	// Кандидаты сверяются со списком необработанных отзывов с api, так как в БД отзыв может
+	// еще числиться необработанным
+	unanswered := make(map[string]bool, len(feedbacks))
+	ids := make([]string, 0, len(feedbacks))
+	for _, f := range feedbacks {
+		unanswered[f.ID] = true
+		ids = append(ids, f.ID)
+	}
+
+	if err := s.db.markMissingFeedbacksAnswered(ids); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.getFeedbacksForReply(dryRun)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении отзывов из БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	var candidates []*feedbackForReply
+	for _, f := range rows {
+		if unanswered[f.id] {
+			candidates = append(candidates, f)
+		}
+	}
+
+	counts, err := s.db.getAutoRepliesCountToday()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении журнала автоответов из БД произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	dailyLimit := config.GetInt("feedbacks.auto_reply_daily_limit")
+
+	var replies []*autoReply
+	for _, f := range candidates {
+		if total >= dailyLimit {
+			slog.Warn(fmt.Sprintf("Достигнут дневной лимит автоответов %d", dailyLimit))
+			break
+		}
+
+		for _, rule := range rules {
+			if !rule.match(f) {
+				continue
+			}
+			if rule.DailyLimit > 0 && counts[rule.Name] >= rule.DailyLimit {
+				break
+			}
+
+			text, ok := rule.render(f)
+			if !ok {
+				continue
+			}
+
+			if !dryRun {
+				if err := s.client.AnswerFeedback(f.id, text); err != nil {
+					slog.Error(fmt.Sprintf("При ответе на отзыв %s произошла ошибка %s", f.id, err.Error()))
+					break
+				}
+				if err := s.db.markFeedbackAnswered(f.id, text); err != nil {
+					return nil, err
+				}
+			}
+			counts[rule.Name]++
+			total++
+
+			if err := s.db.addAutoReplyLog(f, rule.Name, text, dryRun); err != nil {
+				return nil, err
+			}
+			replies = append(replies, &autoReply{feedback: f, rule: rule.Name, text: text})
+
+			break
+		}
+	}
+	slog.Info(fmt.Sprintf("Автоответ подготовлен для %d из %d необработанных отзывов, пробный запуск: %t", len(replies), len(candidates), dryRun))
+
+	return replies, nil
+}
+
+// feedbacksAutoReply отвечает на отзывы по правилам
+func feedbacksAutoReply(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	dryRun := config.GetBool("feedbacks.auto_reply_dry_run")
+	replies, err := autoReplyFeedbacks(s, dryRun)
+	if err != nil {
+		return
+	}
+
+	if dryRun {
+		for _, r := range replies {
+			slog.Info(fmt.Sprintf("Пробный автоответ на отзыв %s по правилу %s: %s", r.feedback.id, r.rule, r.text))
+		}
+	}
+}
+
+// feedbacksAutoReplyCommand отвечает на отзывы по правилам
+func feedbacksAutoReplyCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("feedbacks-auto-reply", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", config.GetBool("feedbacks.auto_reply_dry_run"), "Вывести ответы без отправки")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	replies, err := autoReplyFeedbacks(s, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		for _, r := range replies {
+			fmt.Printf("%s\t%d\t%d\t%s\t%s\n", r.feedback.id, r.feedback.nmID, r.feedback.valuation, r.rule, r.text)
+		}
+	}
+
+	return nil
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/e-vasilyev/wb-tool/internal/wbapi"
 	"github.com/go-co-op/gocron"
 )
 
@@ -17,6 +16,11 @@ var (
 	pdb            *pClinet = &pClinet{pool: nil, ctx: context.Background()}
 )
 
+// newLogger создает логгер с уровнем level. Логи пишутся в stderr, чтобы команды могли выводить файлы в stdout
+func newLogger(level slog.Level) *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+}
+
 func main() {
 	// Инициализация логирования
 	logLevel := slog.LevelInfo
@@ -28,22 +32,13 @@ func main() {
 	case "error":
 		logLevel = slog.LevelError
 	}
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+	logger := newLogger(logLevel)
 	slog.SetDefault(logger)
 
 	// Настройка конфигурации приложения
-	setConfig()
-
-	// Получение токена
-	token := config.GetString("token")
-
-	// Создание клиента WB API
-	wbClient := wbapi.NewClientWithOptions(token, wbapi.SetClientLogger(logger))
-	if err := wbClient.Ping(); err != nil {
-		slog.Error(fmt.Sprintf("При подключении к API получена критическая ошибка %s", err.Error()))
+	if err := setConfig(); err != nil {
+		slog.Error(fmt.Sprintf("При чтении настроек получена критическая ошибка %s", err.Error()))
 		os.Exit(1)
-	} else {
-		slog.Info("Проверка подключения к API прошла успешно")
 	}
 
 	// Подключение к БД
@@ -61,32 +56,71 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Запуск задач
-	scheduler := gocron.NewScheduler(time.Local)
+	// Создание клиентов WB API для кабинетов продавца
+	sellers, err := loadSellers(logger)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При подключении к API получена критическая ошибка %s", err.Error()))
+		os.Exit(1)
+	}
 
-	jobContentSyncCron := scheduler.Cron(config.GetString("cron.content_cards_sync"))
-	if config.GetBool("cron.content_cards_sync_start_immediately") {
-		jobContentSyncCron.StartImmediately()
+	// Запуск команды, если она указана
+	if len(os.Args) > 1 {
+		s, err := selectSeller(sellers)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При выборе кабинета получена критическая ошибка %s", err.Error()))
+			os.Exit(1)
+		}
+		if err := runCommand(s, os.Args[1], os.Args[2:]); err != nil {
+			slog.Error(fmt.Sprintf("При выполнении команды %s произошла ошибка %s", os.Args[1], err.Error()))
+			os.Exit(1)
+		}
+		return
 	}
-	jobContentSync, err := jobContentSyncCron.DoWithJobDetails(contentSync, wbClient)
-	jobContentSync.Name("Синхронизация карточек")
-	jobContentSync.SingletonMode()
 
-	jobStoksSyncCron := scheduler.Cron(config.GetString("cron.stoks_sync"))
-	if config.GetBool("cron.stoks_sync_start_immediately") {
-		jobStoksSyncCron.StartImmediately()
+	// Запуск задач
+	scheduler := gocron.NewScheduler(time.Local)
+
+	jobs := []struct {
+		key    string
+		name   string
+		jobFun interface{}
+	}{
+		{"content_cards_sync", "Синхронизация карточек", contentSync},
+		{"stoks_sync", "Синхронизация остатков", stocksSync},
+		{"checking_time_spent_in_trash", "Проверка времени нахождения карточек в корзине", checkingTimeSpentInTrash},
+		{"marketplace_supply_create", "Сборка новых заданий в поставку", supplyCreate},
+		{"marketplace_offices_sync", "Синхронизация складов WB и складов продавца", officesSync},
+		{"statistics_orders_sync", "Загрузка заказов", statisticsOrdersSync},
+		{"statistics_sales_sync", "Загрузка продаж и возвратов", statisticsSalesSync},
+		{"statistics_incomes_sync", "Загрузка поставок на склады WB", statisticsIncomesSync},
+		{"statistics_report_sync", "Загрузка отчета о реализации за прошлую неделю", statisticsReportSync},
+		{"analytics_paid_reports_sync", "Загрузка отчетов о платном хранении и приемке", paidReportsSync},
+		{"analytics_nm_report_sync", "Загрузка воронки продаж по карточкам", nmReportSync},
+		{"analytics_search_texts_sync", "Загрузка поисковых запросов по карточкам", searchTextsSync},
+		{"feedbacks_sync", "Синхронизация отзывов и вопросов", feedbacksSync},
+		{"feedbacks_auto_reply", "Автоответ на отзывы по правилам", feedbacksAutoReply},
+		{"advert_sync", "Синхронизация рекламных кампаний и их статистики", advertSync},
+		{"advert_stock_control", "Приостановка и запуск рекламных кампаний по остаткам", advertStockControl},
+		{"tariffs_sync", "Загрузка тарифов и комиссий", tariffsSync},
+		{"supplies_acceptance_watch", "Отслеживание слотов приемки на складах WB", acceptanceWatch},
+		{"claims_sync", "Синхронизация заявок покупателей на возврат", claimsSync},
+		{"chats_sync", "Синхронизация чатов с покупателями", chatsSync},
+		{"documents_sync", "Сохранение документов продавца в архив", documentsSync},
+		{"finance_balance_sync", "Сохранение баланса продавца", balanceSync},
+		{"penalties_sync", "Загрузка удержаний и рейтинга продавца", penaltiesSync},
+		{"promotions_sync", "Синхронизация календаря акций", promotionsSync},
+		{"marketplace_delivery_sync", "Синхронизация сборочных заданий DBS и самовывоза", deliveryOrdersSync},
 	}
-	jobStoksSync, err := jobStoksSyncCron.DoWithJobDetails(stocksSync, wbClient)
-	jobStoksSync.Name("Синхронизация остатков")
-	jobStoksSync.SingletonMode()
 
-	jobCheckingTimeSpentInTrashCron := scheduler.Cron(config.GetString("cron.checking_time_spent_in_trash"))
-	if config.GetBool("cron.checking_time_spent_in_trash_start_immediately") {
-		jobCheckingTimeSpentInTrashCron.StartImmediately()
+	// Задачи запускаются отдельно для каждого кабинета
+	for _, s := range sellers {
+		for _, j := range jobs {
+			if err := addJob(scheduler, j.key, s.jobName(j.name), j.jobFun, s); err != nil {
+				slog.Error(fmt.Sprintf("При добавлении задачи '%s' получена критическая ошибка %s", j.name, err.Error()))
+				os.Exit(1)
+			}
+		}
 	}
-	jobCheckingTimeSpentInTrash, err := scheduler.Cron(config.GetString("cron.checking_time_spent_in_trash")).DoWithJobDetails(checkingTimeSpentInTrash, wbClient)
-	jobCheckingTimeSpentInTrash.Name("Проверка времени нахождения карточек в корзине")
-	jobCheckingTimeSpentInTrash.SingletonMode()
 
 	scheduler.RegisterEventListeners(
 		gocron.BeforeJobRuns(func(jobName string) {
@@ -100,3 +134,27 @@ func main() {
 
 	scheduler.StartBlocking()
 }
+
+// addJob добавляет задачу в планировщик по расписанию из настройки cron.<key>.
+// Если расписание не задано, то задача не добавляется
+func addJob(scheduler *gocron.Scheduler, key string, name string, jobFun interface{}, params ...interface{}) error {
+	spec := config.GetString(fmt.Sprintf("cron.%s", key))
+	if spec == "" {
+		slog.Info(fmt.Sprintf("Задача '%s' отключена", name))
+		return nil
+	}
+
+	cron := scheduler.Cron(spec)
+	if config.GetBool(fmt.Sprintf("cron.%s_start_immediately", key)) {
+		cron.StartImmediately()
+	}
+
+	job, err := cron.DoWithJobDetails(jobFun, params...)
+	if err != nil {
+		return err
+	}
+	job.Name(name)
+	job.SingletonMode()
+
+	return nil
+}
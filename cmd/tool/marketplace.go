@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log/slog"
 
-	"github.com/e-vasilyev/wb-tool/internal/wbapi"
 	"github.com/go-co-op/gocron"
 )
 
@@ -29,8 +28,8 @@ func (m *marketplaceStocks) count() int {
 }
 
 // newMarketplsceStocks создает спиоск остатков на складах продавца.
-func newMarketplsceStocks(wbClient *wbapi.Client, skus []string) (*marketplaceStocks, error) {
-	wbWarehouses, err := wbClient.GetWarehouses()
+func newMarketplsceStocks(s *seller, skus []string) (*marketplaceStocks, error) {
+	wbWarehouses, err := s.client.GetWarehouses()
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +37,7 @@ func newMarketplsceStocks(wbClient *wbapi.Client, skus []string) (*marketplaceSt
 	result := &marketplaceStocks{stocks: make(map[string]*marketplaceStock)}
 
 	for _, wbWarehouse := range wbWarehouses {
-		wbStocks, err := wbClient.GetStocks(*wbWarehouse, skus)
+		wbStocks, err := s.client.GetStocks(*wbWarehouse, skus)
 		if err != nil {
 			return nil, err
 		}
@@ -56,10 +55,10 @@ func newMarketplsceStocks(wbClient *wbapi.Client, skus []string) (*marketplaceSt
 }
 
 // stocksSync синхронизирует остатки по карточкам
-func stocksSync(wbClient *wbapi.Client, job gocron.Job) {
+func stocksSync(s *seller, job gocron.Job) {
 	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
 
-	skusRows, err := pdb.getContentSkusTable()
+	skusRows, err := s.db.getContentSkusTable()
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении списка баркодов из БД произошла ошибка %s", err.Error()))
 		return
@@ -71,35 +70,35 @@ func stocksSync(wbClient *wbapi.Client, job gocron.Job) {
 		skus = append(skus, row.Sku)
 	}
 
-	marketplsceStocks, err := newMarketplsceStocks(wbClient, skus)
+	marketplsceStocks, err := newMarketplsceStocks(s, skus)
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении остатков склада продавца произошла ошибка %s", err.Error()))
 		return
 	}
 	slog.Info(fmt.Sprintf("Получено %d баркодов", marketplsceStocks.count()))
 
-	if err := pdb.syncMarketplaceStocks(marketplsceStocks, skusRows); err != nil {
+	if err := s.db.syncMarketplaceStocks(marketplsceStocks, skusRows); err != nil {
 		slog.Error(fmt.Sprintf("При синхронизации остатков складов продавца произошла ошибка %s", err.Error()))
 		return
 	}
 
-	supplierStocks, err := newSupplierStocks(wbClient, config.GetString("statistics.date_from"))
+	supplierStocks, err := newSupplierStocks(s, config.GetString("statistics.date_from"))
 	if err != nil {
 		slog.Error(fmt.Sprintf("При получении остатков складов WB произошла ошибка %s", err.Error()))
 		return
 	}
 
-	if err := pdb.syncSupplierStocks(supplierStocks, skusRows); err != nil {
+	if err := s.db.syncSupplierStocks(supplierStocks, skusRows); err != nil {
 		slog.Error(fmt.Sprintf("При синхронизации остатков складов WB произошла ошибка %s", err.Error()))
 		return
 	}
 }
 
 // getSkusForDelete получает список skus для удаления
-func (m *marketplaceStocks) getSkusForDelete() ([]string, error) {
+func (m *marketplaceStocks) getSkusForDelete(p *pClinet) ([]string, error) {
 	var res []string
 
-	skus, err := pdb.getSkusMarketplaceStocksTable()
+	skus, err := p.getSkusMarketplaceStocksTable()
 	if err != nil {
 		return []string{}, err
 	}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// syncSupplyOrders сохраняет сборочные задания поставки в БД
+func syncSupplyOrders(s *seller, supplyID string) error {
+	orders, err := s.client.GetSupplyOrders(supplyID)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		if err := s.db.upsertSupplyOrder(supplyID, order); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSupplyMeta проверяет, что у сборочных заданий поставки заполнены обязательные метаданные.
+// Для карточек с признаком kiz_required обязательным считается код маркировки (sgtin)
+func validateSupplyMeta(s *seller, supplyID string) error {
+	if err := syncSupplyOrders(s, supplyID); err != nil {
+		return err
+	}
+
+	orders, err := s.db.getSupplyOrdersRequiredMeta(supplyID)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+	for _, order := range orders {
+		keys := order.RequiredMeta
+		if order.KizRequired && !slices.Contains(keys, wbapi.OrderMetaSGTIN) {
+			keys = append(keys, wbapi.OrderMetaSGTIN)
+		}
+
+		if len(keys) == 0 {
+			continue
+		}
+
+		meta, err := s.client.GetOrderMeta(order.OrderID)
+		if err != nil {
+			return err
+		}
+
+		var missing []string
+		for _, key := range keys {
+			if !meta.Has(key) {
+				missing = append(missing, key)
+			}
+		}
+
+		if len(missing) > 0 {
+			slog.Warn(fmt.Sprintf("У сборочного задания %d (карточка %d) не заполнены метаданные: %s", order.OrderID, order.NmID, strings.Join(missing, ", ")))
+			problems = append(problems, fmt.Sprintf("%d: %s", order.OrderID, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("в поставке %s есть сборочные задания без обязательных метаданных: %s", supplyID, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// parseOrderID разбирает ID сборочного задания из аргументов команды
+func parseOrderID(args []string) (uint64, error) {
+	if len(args) != 1 {
+		return 0, errors.New("не указан ID сборочного задания")
+	}
+
+	return strconv.ParseUint(args[0], 10, 64)
+}
+
+// orderCancelCommand отменяет сборочное задание
+func orderCancelCommand(s *seller, args []string) error {
+	orderID, err := parseOrderID(args)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.CancelOrder(orderID); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Сборочное задание %d отменено", orderID))
+
+	return s.db.markSupplyOrderCanceled(orderID)
+}
+
+// orderMetaCommand выводит метаданные сборочного задания
+func orderMetaCommand(s *seller, args []string) error {
+	orderID, err := parseOrderID(args)
+	if err != nil {
+		return err
+	}
+
+	meta, err := s.client.GetOrderMeta(orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range []string{wbapi.OrderMetaSGTIN, wbapi.OrderMetaUIN, wbapi.OrderMetaIMEI, wbapi.OrderMetaGTIN} {
+		if value := meta.Get(key); value != nil {
+			fmt.Printf("%s\t%v\n", key, value.Value)
+		}
+	}
+
+	return nil
+}
+
+// orderMetaSetCommand закрепляет метаданные за сборочным заданием
+func orderMetaSetCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("order-meta-set", flag.ContinueOnError)
+	sgtin := flags.String("sgtin", "", "Коды маркировки Честного знака через запятую")
+	uin := flags.String("uin", "", "УИН ювелирного изделия")
+	imei := flags.String("imei", "", "IMEI устройства")
+	gtin := flags.String("gtin", "", "GTIN товара")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	orderID, err := parseOrderID(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if *sgtin != "" {
+		if err := s.client.SetOrderSGTIN(orderID, strings.Split(*sgtin, ",")); err != nil {
+			return err
+		}
+	}
+
+	if *uin != "" {
+		if err := s.client.SetOrderUIN(orderID, *uin); err != nil {
+			return err
+		}
+	}
+
+	if *imei != "" {
+		if err := s.client.SetOrderIMEI(orderID, *imei); err != nil {
+			return err
+		}
+	}
+
+	if *gtin != "" {
+		if err := s.client.SetOrderGTIN(orderID, *gtin); err != nil {
+			return err
+		}
+	}
+	slog.Info(fmt.Sprintf("Метаданные сборочного задания %d обновлены", orderID))
+
+	return nil
+}
+
+// orderMetaDeleteCommand удаляет метаданные сборочного задания
+func orderMetaDeleteCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("order-meta-delete", flag.ContinueOnError)
+	key := flags.String("key", "", "Ключ метаданных: sgtin, uin, imei, gtin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *key == "" {
+		return errors.New("не указан ключ метаданных")
+	}
+
+	orderID, err := parseOrderID(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.DeleteOrderMeta(orderID, *key); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Метаданные %s сборочного задания %d удалены", *key, orderID))
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// supplyName возвращает имя поставки для указанной даты
+func supplyName(t time.Time) string {
+	return fmt.Sprintf("wb-tool %s", t.Format("2006-01-02"))
+}
+
+// batchNewOrders добавляет новые сборочные задания в открытую поставку текущего дня.
+// Если поставки нет или она уже передана в доставку, то создается новая. Возвращает ID поставки и количество добавленных заданий
+func batchNewOrders(s *seller) (string, int, error) {
+	orders, err := s.client.GetNewOrders()
+	if err != nil {
+		return "", 0, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d новых сборочных заданий", len(orders)))
+
+	if len(orders) == 0 {
+		return "", 0, nil
+	}
+
+	name := supplyName(time.Now())
+
+	supplyID, err := s.db.getOpenSupplyID(name)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// Поставка могла быть передана в доставку в кабинете, тогда в нее нельзя добавлять задания
+	if supplyID != "" {
+		supply, err := s.client.GetSupply(supplyID)
+		if err != nil {
+			return "", 0, err
+		}
+
+		if err := s.db.upsertSupply(supply); err != nil {
+			return "", 0, err
+		}
+
+		if supply.Done {
+			slog.Info(fmt.Sprintf("Поставка %s уже передана в доставку", supplyID))
+			supplyID = ""
+		}
+	}
+
+	if supplyID == "" {
+		supplyID, err = s.client.CreateSupply(name)
+		if err != nil {
+			return "", 0, err
+		}
+		slog.Info(fmt.Sprintf("Создана поставка %s", supplyID))
+
+		if err := syncSupply(s, supplyID); err != nil {
+			return "", 0, err
+		}
+	}
+
+	var added int
+	for _, order := range orders {
+		if err := s.client.AddOrderToSupply(supplyID, order.ID); err != nil {
+			slog.Error(fmt.Sprintf("При добавлении сборочного задания %d в поставку %s произошла ошибка %s", order.ID, supplyID, err.Error()))
+			continue
+		}
+
+		if err := s.db.upsertSupplyOrder(supplyID, order); err != nil {
+			return supplyID, added, err
+		}
+		added++
+	}
+
+	return supplyID, added, nil
+}
+
+// syncSupply получает информацию о поставке и сохраняет ее в БД
+func syncSupply(s *seller, supplyID string) error {
+	supply, err := s.client.GetSupply(supplyID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.upsertSupply(supply)
+}
+
+// supplyCreate собирает новые сборочные задания в поставку
+func supplyCreate(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	supplyID, added, err := batchNewOrders(s)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При сборке поставки произошла ошибка %s", err.Error()))
+		return
+	}
+
+	if added == 0 {
+		return
+	}
+	slog.Info(fmt.Sprintf("В поставку %s добавлено %d сборочных заданий", supplyID, added))
+
+	if !config.GetBool("marketplace.stickers_archive") {
+		return
+	}
+
+	size, err := wbapi.ParseStickerSize(config.GetString("marketplace.stickers_size"))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении размера стикеров произошла ошибка %s", err.Error()))
+		return
+	}
+
+	stickerType := wbapi.StickerType(config.GetString("marketplace.stickers_type"))
+	if _, err := archiveSupplyStickers(s, supplyID, stickerType, size); err != nil {
+		slog.Error(fmt.Sprintf("При сохранении стикеров поставки %s произошла ошибка %s", supplyID, err.Error()))
+	}
+}
+
+// suppliesCommand синхронизирует поставки с БД и выводит их список
+func suppliesCommand(s *seller, args []string) error {
+	supplies, err := s.client.GetSupplies()
+	if err != nil {
+		return err
+	}
+
+	for _, supply := range supplies {
+		if err := s.db.upsertSupply(supply); err != nil {
+			return err
+		}
+
+		status := "открыта"
+		if supply.Done {
+			status = "закрыта"
+		}
+		fmt.Printf("%s\t%s\t%s\t%s\n", supply.ID, supply.Name, supply.CreatedAt, status)
+	}
+
+	return nil
+}
+
+// supplyCreateCommand собирает новые сборочные задания в поставку
+func supplyCreateCommand(s *seller, args []string) error {
+	supplyID, added, err := batchNewOrders(s)
+	if err != nil {
+		return err
+	}
+
+	if added == 0 {
+		fmt.Println("Новых сборочных заданий нет")
+		return nil
+	}
+
+	fmt.Printf("%s\t%d\n", supplyID, added)
+
+	return nil
+}
+
+// supplyDeliverCommand закрывает поставку и передает ее в доставку.
+// Перед передачей проверяется заполнение обязательных метаданных сборочных заданий
+func supplyDeliverCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("supply-deliver", flag.ContinueOnError)
+	force := flags.Bool("force", false, "Передать поставку без проверки метаданных сборочных заданий")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("не указан ID поставки")
+	}
+	supplyID := flags.Arg(0)
+
+	if !*force {
+		if err := validateSupplyMeta(s, supplyID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.client.DeliverSupply(supplyID); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Поставка %s передана в доставку", supplyID))
+
+	return syncSupply(s, supplyID)
+}
+
+// supplyBarcodeCommand сохраняет QR-код поставки в файл
+func supplyBarcodeCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("supply-barcode", flag.ContinueOnError)
+	stickerType := flags.String("type", string(wbapi.StickerTypePNG), "Формат QR-кода: svg, zplv, zplh, png")
+	out := flags.String("out", "", "Файл для сохранения QR-кода. По умолчанию <supply_id>.<type>")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("не указан ID поставки")
+	}
+	supplyID := flags.Arg(0)
+
+	barcode, err := s.client.GetSupplyBarcode(supplyID, wbapi.StickerType(*stickerType))
+	if err != nil {
+		return err
+	}
+
+	file, err := base64.StdEncoding.DecodeString(barcode.File)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		*out = fmt.Sprintf("%s.%s", supplyID, *stickerType)
+	}
+
+	if err := os.WriteFile(*out, file, 0644); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("QR-код поставки %s (%s) сохранен в %s", supplyID, barcode.Barcode, *out))
+
+	return nil
+}
+
+// supplyCancelCommand удаляет пустую поставку
+func supplyCancelCommand(s *seller, args []string) error {
+	if len(args) != 1 {
+		return errors.New("не указан ID поставки")
+	}
+	supplyID := args[0]
+
+	if err := s.client.CancelSupply(supplyID); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Поставка %s удалена", supplyID))
+
+	return s.db.deleteSupply(supplyID)
+}
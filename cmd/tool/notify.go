@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// notifyClient http клиент для отправки уведомлений
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// notify записывает уведомление в лог и отправляет его в Telegram и на webhook, если они настроены.
+// Ошибки отправки только логируются, чтобы не прерывать задачу
+func notify(message string) {
+	slog.Warn(fmt.Sprintf("Уведомление: %s", message))
+
+	if token, chatID := config.GetString("notify.telegram_token"), config.GetString("notify.telegram_chat_id"); token != "" && chatID != "" {
+		if err := notifyTelegram(token, chatID, message); err != nil {
+			slog.Error(fmt.Sprintf("При отправке уведомления в Telegram произошла ошибка %s", err.Error()))
+		}
+	}
+
+	if webhookURL := config.GetString("notify.webhook_url"); webhookURL != "" {
+		if err := notifyWebhook(webhookURL, message); err != nil {
+			slog.Error(fmt.Sprintf("При отправке уведомления на webhook произошла ошибка %s", err.Error()))
+		}
+	}
+}
+
+// notifyTelegram отправляет сообщение в чат Telegram
+func notifyTelegram(token string, chatID string, message string) error {
+	form := url.Values{}
+	form.Set("chat_id", chatID)
+	form.Set("text", message)
+
+	resp, err := notifyClient.PostForm(fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", token), form)
+	if err != nil {
+		// Ошибка запроса содержит URL с токеном бота, поэтому возвращается только ее причина
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	return notifyRespCheck(resp)
+}
+
+// notifyWebhook отправляет сообщение на webhook в виде JSON {"text": "..."}
+func notifyWebhook(webhookURL string, message string) error {
+	body, err := json.Marshal(map[string]string{"text": message})
+	if err != nil {
+		return err
+	}
+
+	resp, err := notifyClient.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return notifyRespCheck(resp)
+}
+
+// notifyRespCheck проверяет код ответа на отправку уведомления
+func notifyRespCheck(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+
+	return fmt.Errorf("код ответа %d: %s", resp.StatusCode, string(body))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+
+	_ "image/png"
+)
+
+// pdfPage описывает страницу PDF документа с одним изображением
+type pdfPage struct {
+	width  float64
+	height float64
+	img    image.Image
+}
+
+// writeImagesPDF записывает PNG изображения в PDF документ по одному на страницу.
+// Ширина страницы задается в миллиметрах, высота рассчитывается по пропорциям изображения
+func writeImagesPDF(w io.Writer, images [][]byte, widthMM uint32) error {
+	var pages []*pdfPage
+
+	for _, data := range images {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+
+		bounds := img.Bounds()
+		width := float64(widthMM) * 72 / 25.4
+		height := width * float64(bounds.Dy()) / float64(bounds.Dx())
+
+		pages = append(pages, &pdfPage{width: width, height: height, img: img})
+	}
+
+	return writePDF(w, pages)
+}
+
+// writePDF записывает страницы в PDF документ
+// Объекты документа: 1 - каталог, 2 - список страниц, далее по три объекта на страницу
+func writePDF(w io.Writer, pages []*pdfPage) error {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string, stream []byte) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\n", len(offsets), body)
+		if stream != nil {
+			buf.WriteString("stream\n")
+			buf.Write(stream)
+			buf.WriteString("\nendstream\n")
+		}
+		buf.WriteString("endobj\n")
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	var kids bytes.Buffer
+	for i := range pages {
+		fmt.Fprintf(&kids, "%d 0 R ", 3+i*3)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>", nil)
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), len(pages)), nil)
+
+	for i, page := range pages {
+		pageID := 3 + i*3
+
+		imgData, err := pdfImageData(page.img)
+		if err != nil {
+			return err
+		}
+		bounds := page.img.Bounds()
+		content := []byte(fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q", page.width, page.height))
+
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+			page.width, page.height, pageID+2, pageID+1,
+		), nil)
+		object(fmt.Sprintf("<< /Length %d >>", len(content)), content)
+		object(fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+			bounds.Dx(), bounds.Dy(), len(imgData),
+		), imgData)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+
+	return err
+}
+
+// pdfImageData возвращает пиксели изображения в формате RGB сжатые zlib
+func pdfImageData(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := zlib.NewWriter(&buf)
+	bounds := img.Bounds()
+	row := make([]byte, 0, bounds.Dx()*3)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Прозрачные пиксели накладываются на белый фон, цвета уже умножены на альфа-канал
+			r, g, b = r+0xffff-a, g+0xffff-a, b+0xffff-a
+			row = append(row, byte(r>>8), byte(g>>8), byte(b>>8))
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// testPNG возвращает PNG изображение размером width x height.
+// Левый верхний пиксель прозрачный, остальные черные
+func testPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{A: 0xff})
+		}
+	}
+	img.Set(0, 0, color.NRGBA{})
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestWriteImagesPDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeImagesPDF(&buf, [][]byte{testPNG(t, 4, 2), testPNG(t, 3, 3)}, 58); err != nil {
+		t.Fatal(err)
+	}
+	pdf := buf.Bytes()
+
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+		t.Fatalf("нет заголовка PDF: %q", pdf[:min(len(pdf), 16)])
+	}
+	if !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatal("нет маркера конца PDF")
+	}
+
+	// startxref указывает на таблицу xref
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	if m == nil {
+		t.Fatal("не найден startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d не указывает на таблицу xref", xref)
+	}
+
+	// Каталог, список страниц и по три объекта на страницу
+	m = regexp.MustCompile(`xref\n0 (\d+)\n`).FindSubmatch(pdf[xref:])
+	size, _ := strconv.Atoi(string(m[1]))
+	if size != 1+2+2*3 {
+		t.Fatalf("количество объектов %d, ожидалось %d", size, 1+2+2*3)
+	}
+	if !bytes.Contains(pdf, []byte(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>", size))) {
+		t.Fatal("trailer не соответствует таблице xref")
+	}
+
+	// Смещения xref указывают на начало объектов
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	if len(offsets) != size-1 {
+		t.Fatalf("в таблице xref %d смещений, ожидалось %d", len(offsets), size-1)
+	}
+	for i, o := range offsets {
+		offset, _ := strconv.Atoi(string(o[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+			t.Errorf("смещение %d не указывает на объект %d", offset, i+1)
+		}
+	}
+
+	if !bytes.Contains(pdf, []byte("<< /Type /Pages /Kids [3 0 R 6 0 R ] /Count 2 >>")) {
+		t.Error("неверный список страниц")
+	}
+
+	// Ширина страницы 58 мм в пунктах, высота по пропорциям изображения
+	for _, box := range []string{"/MediaBox [0 0 164.41 82.20]", "/MediaBox [0 0 164.41 164.41]"} {
+		if !bytes.Contains(pdf, []byte(box)) {
+			t.Errorf("не найдена страница %s", box)
+		}
+	}
+
+	// Длина потоков совпадает с /Length, изображения распаковываются в RGB
+	streams := regexp.MustCompile(`(?s)<< ([^\n]*)/Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(pdf, -1)
+	if len(streams) != 4 {
+		t.Fatalf("найдено %d потоков, ожидалось 4", len(streams))
+	}
+
+	var images [][]byte
+	for _, s := range streams {
+		length, _ := strconv.Atoi(string(pdf[s[4]:s[5]]))
+		data := pdf[s[1] : s[1]+length]
+		if !bytes.HasPrefix(pdf[s[1]+length:], []byte("\nendstream\n")) {
+			t.Fatalf("длина потока %d не совпадает с /Length", length)
+		}
+
+		if bytes.Contains(pdf[s[2]:s[3]], []byte("/Subtype /Image")) {
+			zr, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pixels, err := io.ReadAll(zr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			images = append(images, pixels)
+		}
+	}
+
+	if len(images) != 2 || len(images[0]) != 4*2*3 || len(images[1]) != 3*3*3 {
+		t.Fatalf("неверный размер изображений")
+	}
+	// Прозрачный пиксель на белом фоне, непрозрачный остается черным
+	if !bytes.Equal(images[0][:6], []byte{0xff, 0xff, 0xff, 0, 0, 0}) {
+		t.Errorf("неверные пиксели изображения %v", images[0][:6])
+	}
+}
+
+func TestWriteImagesPDFInvalidImage(t *testing.T) {
+	if err := writeImagesPDF(io.Discard, [][]byte{[]byte("not png")}, 58); err == nil {
+		t.Error("ожидалась ошибка для неверного изображения")
+	}
+}
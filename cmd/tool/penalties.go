@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// Виды удержаний в таблице wb_penalties
+const (
+	penaltyKindAntifraud             string = "antifraud"
+	penaltyKindGoodsLabeling         string = "goods_labeling"
+	penaltyKindIncorrectAttachment   string = "incorrect_attachment"
+	penaltyKindCharacteristicsChange string = "characteristics_change"
+)
+
+// getPenalties возвращает удержания и штрафы за последние дни, заданные настройкой penalties.days
+func getPenalties(s *seller) ([]*penalty, error) {
+	dateFrom := time.Now().AddDate(0, 0, -config.GetInt("penalties.days")).Format("2006-01-02")
+	dateTo := time.Now().Format("2006-01-02")
+
+	var penalties []*penalty
+
+	antifraud, err := s.client.GetAntifraudDetails()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range antifraud {
+		penalties = append(penalties, &penalty{
+			kind: penaltyKindAntifraud, id: fmt.Sprintf("%d-%s", d.NmID, d.DateFrom), nmID: d.NmID,
+			date: d.DateFrom, amount: d.Sum, reason: "Самовыкуп",
+		})
+	}
+
+	labeling, err := s.client.GetGoodsLabelingFines(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range labeling {
+		penalties = append(penalties, &penalty{
+			kind: penaltyKindGoodsLabeling, id: fmt.Sprintf("%d-%s", f.ShkID, f.Date), nmID: f.NmID,
+			date: f.Date, amount: f.Amount, reason: "Отсутствие маркировки",
+		})
+	}
+
+	attachments, err := s.client.GetIncorrectAttachmentFines(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range attachments {
+		penalties = append(penalties, &penalty{
+			kind: penaltyKindIncorrectAttachment, id: fmt.Sprintf("%d-%s", f.ShkID, f.Date), nmID: f.NmID,
+			date: f.Date, amount: f.Amount, reason: f.LostReason,
+		})
+	}
+
+	characteristics, err := s.client.GetCharacteristicsChangeFines(dateFrom, dateTo)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range characteristics {
+		// За день по карточке может быть несколько смен характеристик, поэтому штраф определяется парой характеристик
+		penalties = append(penalties, &penalty{
+			kind: penaltyKindCharacteristicsChange, id: fmt.Sprintf("%d-%d", f.OldChID, f.NewChID), nmID: f.NmID,
+			date: f.Date, amount: f.Amount, reason: "Смена характеристик",
+		})
+	}
+
+	return penalties, nil
+}
+
+// penaltiesSync загружает удержания и штрафы и рейтинг продавца за текущий день.
+// О новых удержаниях и снижении рейтинга отправляется уведомление
+func penaltiesSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	penalties, err := getPenalties(s)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении удержаний произошла ошибка %s", err.Error()))
+	} else {
+		slog.Info(fmt.Sprintf("Получено %d удержаний", len(penalties)))
+
+		added, err := s.db.addPenalties(penalties)
+		if err != nil {
+			slog.Error(fmt.Sprintf("При синхронизации удержаний произошла ошибка %s", err.Error()))
+		} else if len(added) > 0 {
+			var lines []string
+			for _, p := range added {
+				lines = append(lines, fmt.Sprintf("%s: карточка %d, %.2f руб., %s", p.date, p.nmID, p.amount, p.reason))
+			}
+			s.notify(fmt.Sprintf("Новые удержания (%d):\n%s", len(added), strings.Join(lines, "\n")))
+		}
+	}
+
+	feedbacks, err := s.client.GetFeedbacksCountUnanswered()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении рейтинга продавца произошла ошибка %s", err.Error()))
+		return
+	}
+
+	questions, err := s.client.GetQuestionsCountUnanswered()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении количества необработанных вопросов произошла ошибка %s", err.Error()))
+		return
+	}
+
+	var valuation *float64
+	if v, err := strconv.ParseFloat(strings.ReplaceAll(feedbacks.Valuation, ",", "."), 64); err == nil {
+		valuation = &v
+	}
+
+	previous, err := s.db.upsertSellerRating(valuation, feedbacks, questions)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации рейтинга продавца произошла ошибка %s", err.Error()))
+		return
+	}
+
+	if previous != nil && valuation != nil && *valuation < *previous {
+		s.notify(fmt.Sprintf("Рейтинг продавца снизился с %.2f до %.2f", *previous, *valuation))
+	}
+}
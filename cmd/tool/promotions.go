@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// syncPromotionsFromAPI синхронизирует акции на ближайшие дни, заданные настройкой promotions.days_ahead,
+// и возвращает их
+func syncPromotionsFromAPI(s *seller) ([]*wbapi.Promotion, error) {
+	start := time.Now()
+	end := start.AddDate(0, 0, config.GetInt("promotions.days_ahead"))
+
+	promotions, err := s.client.GetPromotions(start, end)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении акций произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d акций", len(promotions)))
+
+	var ids []uint32
+	for _, pr := range promotions {
+		ids = append(ids, pr.ID)
+	}
+
+	details, err := s.client.GetPromotionDetails(ids)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении информации об акциях произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	if err := s.db.syncPromotions(details); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации акций произошла ошибка %s", err.Error()))
+		return nil, err
+	}
+
+	return details, nil
+}
+
+// promotionsSync синхронизирует календарь акций и напоминает об акциях, которые скоро начнутся,
+// если в них еще не добавлялись карточки
+func promotionsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if _, err := syncPromotionsFromAPI(s); err != nil {
+		return
+	}
+
+	deadlines, err := s.db.getPromotionDeadlines(time.Now().AddDate(0, 0, config.GetInt("promotions.notify_days")))
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении ближайших акций произошла ошибка %s", err.Error()))
+		return
+	}
+
+	if len(deadlines) == 0 {
+		return
+	}
+
+	var (
+		ids   []uint32
+		lines []string
+	)
+	for _, d := range deadlines {
+		ids = append(ids, d.id)
+		lines = append(lines, fmt.Sprintf("%d: %s, начало %s", d.id, d.name, d.start.Format("2006-01-02 15:04")))
+	}
+	s.notify(fmt.Sprintf("Скоро начнутся акции без ваших карточек (%d):\n%s", len(deadlines), strings.Join(lines, "\n")))
+
+	if err := s.db.markPromotionsNotified(ids); err != nil {
+		return
+	}
+}
+
+// promotionMargin возвращает маржу в процентах от цены в акции с учетом себестоимости и комиссии.
+// Себестоимость и комиссия карточки должны быть известны
+func promotionMargin(price float64, card *promotionCard) float64 {
+	return (price - *card.costPrice - *card.commission*price/100) / price * 100
+}
+
+// enrollPromotion добавляет в акцию карточки, у которых маржа по цене акции не ниже minMargin
+// и остаток на складах WB не меньше minStock
+func enrollPromotion(s *seller, promotionID uint32, minMargin float64, minStock int, dryRun bool) error {
+	nomenclatures, err := s.client.GetPromotionNomenclatures(promotionID, false)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении карточек акции %d произошла ошибка %s", promotionID, err.Error()))
+		return err
+	}
+	slog.Info(fmt.Sprintf("Получено %d карточек, которые можно добавить в акцию %d", len(nomenclatures), promotionID))
+
+	var nmIDs []uint32
+	for _, n := range nomenclatures {
+		nmIDs = append(nmIDs, n.ID)
+	}
+
+	cards, err := s.db.getPromotionCards(nmIDs)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении карточек из БД произошла ошибка %s", err.Error()))
+		return err
+	}
+
+	var enroll []uint32
+	for _, n := range nomenclatures {
+		card, ok := cards[n.ID]
+		switch {
+		case !ok:
+			slog.Warn(fmt.Sprintf("Карточка %d не найдена в БД", n.ID))
+			continue
+		case card.costPrice == nil:
+			slog.Warn(fmt.Sprintf("Для карточки %d не указана себестоимость", n.ID))
+			continue
+		case card.commission == nil:
+			slog.Warn(fmt.Sprintf("Для карточки %d не найдена комиссия, нужна задача tariffs_sync", n.ID))
+			continue
+		case n.PlanPrice <= 0:
+			continue
+		}
+
+		margin := promotionMargin(n.PlanPrice, card)
+		if margin < minMargin || card.stock < minStock {
+			slog.Debug(fmt.Sprintf("Карточка %d пропущена: маржа %.2f%%, остаток %d", n.ID, margin, card.stock))
+			continue
+		}
+
+		fmt.Printf("%d\t%.2f\t%.2f\t%.2f%%\t%d\n", n.ID, n.Price, n.PlanPrice, margin, card.stock)
+		enroll = append(enroll, n.ID)
+	}
+
+	if dryRun || len(enroll) == 0 {
+		slog.Info(fmt.Sprintf("В акцию %d подходит %d карточек", promotionID, len(enroll)))
+		return nil
+	}
+
+	uploads, uploadErr := s.client.UploadPromotionNomenclatures(promotionID, enroll)
+	for _, u := range uploads {
+		slog.Info(fmt.Sprintf("%d карточек добавлено в акцию %d, загрузка %d", len(u.NmIDs), promotionID, u.UploadID))
+		if err := s.db.addPromotionUpload(u.UploadID, promotionID, u.NmIDs); err != nil {
+			return err
+		}
+	}
+	if uploadErr != nil {
+		slog.Error(fmt.Sprintf("При добавлении карточек в акцию %d произошла ошибка %s", promotionID, uploadErr.Error()))
+		return uploadErr
+	}
+
+	return nil
+}
+
+// promotionsCommand синхронизирует и выводит акции на ближайшие дни
+func promotionsCommand(s *seller, args []string) error {
+	promotions, err := syncPromotionsFromAPI(s)
+	if err != nil {
+		return err
+	}
+
+	for _, pr := range promotions {
+		fmt.Printf(
+			"%d\t%s\t%s\t%s\t%d/%d\t%s\n",
+			pr.ID, pr.StartDateTime, pr.EndDateTime, pr.Type, pr.InPromoActionTotal,
+			pr.InPromoActionTotal+pr.NotInPromoActionTotal, pr.Name,
+		)
+	}
+
+	return nil
+}
+
+// promotionEnrollCommand добавляет карточки в акцию по правилу минимальной маржи и остатка
+func promotionEnrollCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("promotion-enroll", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Вывести подходящие карточки без добавления в акцию")
+	minMargin := flags.Float64("min-margin", config.GetFloat64("promotions.min_margin"), "Минимальная маржа в процентах")
+	minStock := flags.Int("min-stock", config.GetInt("promotions.min_stock"), "Минимальный остаток на складах WB")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() < 1 {
+		return errors.New("не указан идентификатор акции")
+	}
+
+	promotionID, err := strconv.ParseUint(flags.Arg(0), 10, 32)
+	if err != nil {
+		return fmt.Errorf("неверный идентификатор акции %s", flags.Arg(0))
+	}
+
+	return enrollPromotion(s, uint32(promotionID), *minMargin, *minStock, *dryRun)
+}
+
+// cardCostPriceCommand записывает себестоимость карточки
+func cardCostPriceCommand(s *seller, args []string) error {
+	if len(args) < 2 {
+		return errors.New("не указан артикул WB или себестоимость")
+	}
+
+	nmID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("неверный артикул WB %s", args[0])
+	}
+
+	costPrice, err := strconv.ParseFloat(strings.ReplaceAll(args[1], ",", "."), 64)
+	if err != nil || costPrice < 0 {
+		return fmt.Errorf("неверная себестоимость %s", args[1])
+	}
+
+	return s.db.setCardCostPrice(uint32(nmID), costPrice)
+}
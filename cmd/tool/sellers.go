@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// defaultSellerName имя кабинета, если кабинеты не указаны в настройке sellers.
+// Данные, загруженные до появления кабинетов, принадлежат этому кабинету или первому кабинету из настройки sellers
+const defaultSellerName = "default"
+
+// sellerConfig описывает кабинет продавца в файле настроек
+type sellerConfig struct {
+	Name     string `mapstructure:"name"`
+	TokenEnv string `mapstructure:"token_env"`
+}
+
+// seller описывает кабинет продавца: клиент WB API с собственными ограничениями запросов
+// и подключение к БД, запросы которого ограничены данными кабинета
+type seller struct {
+	id       int32
+	name     string
+	client   *wbapi.Client
+	db       *pClinet
+	multiple bool
+}
+
+// loadSellers создает кабинеты из настройки sellers и проверяет подключение к API для каждого из них.
+// Если кабинеты не указаны, то используется один кабинет default с токеном из настройки token
+func loadSellers(logger *slog.Logger) ([]*seller, error) {
+	var configs []sellerConfig
+	if err := config.UnmarshalKey("sellers", &configs); err != nil {
+		return nil, err
+	}
+
+	if len(configs) == 0 {
+		configs = []sellerConfig{{Name: defaultSellerName}}
+	}
+
+	// Данные, загруженные до появления кабинетов, получает первый кабинет, если кабинета default нет в настройке
+	if !slices.ContainsFunc(configs, func(c sellerConfig) bool { return c.Name == defaultSellerName }) && configs[0].Name != "" {
+		if err := pdb.claimDefaultSeller(configs[0].Name); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make(map[string]bool, len(configs))
+	var sellers []*seller
+	for _, c := range configs {
+		if c.Name == "" {
+			return nil, errors.New("в настройке sellers не указано имя кабинета")
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("кабинет %s указан в настройке sellers несколько раз", c.Name)
+		}
+		names[c.Name] = true
+
+		token := config.GetString("token")
+		if c.TokenEnv != "" {
+			token = os.Getenv(c.TokenEnv)
+		}
+		if token == "" {
+			return nil, fmt.Errorf("не задан токен кабинета %s", c.Name)
+		}
+
+		id, err := pdb.upsertSeller(c.Name, c.TokenEnv)
+		if err != nil {
+			return nil, err
+		}
+
+		client := wbapi.NewClientWithOptions(token, wbapi.SetClientLogger(logger))
+		if err := client.Ping(); err != nil {
+			return nil, fmt.Errorf("при подключении к API кабинета %s получена ошибка %s", c.Name, err.Error())
+		}
+		slog.Info(fmt.Sprintf("Проверка подключения к API кабинета %s прошла успешно", c.Name))
+
+		sellers = append(sellers, &seller{
+			id:       id,
+			name:     c.Name,
+			client:   client,
+			db:       pdb.forSeller(id),
+			multiple: len(configs) > 1,
+		})
+	}
+
+	return sellers, nil
+}
+
+// selectSeller возвращает кабинет для запуска команды из настройки seller.
+// Если кабинет один, то настройку можно не указывать
+func selectSeller(sellers []*seller) (*seller, error) {
+	name := config.GetString("seller")
+	if name == "" {
+		if len(sellers) == 1 {
+			return sellers[0], nil
+		}
+		return nil, errors.New("для запуска команды укажите кабинет в настройке seller")
+	}
+
+	for _, s := range sellers {
+		if s.name == name {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("кабинет %s не найден в настройке sellers", name)
+}
+
+// jobName возвращает название задачи кабинета. Если кабинетов несколько, то к названию добавляется имя кабинета
+func (s *seller) jobName(name string) string {
+	if !s.multiple {
+		return name
+	}
+
+	return fmt.Sprintf("%s (%s)", name, s.name)
+}
+
+// notify отправляет уведомление кабинета. Если кабинетов несколько, то в начало уведомления добавляется имя кабинета
+func (s *seller) notify(message string) {
+	if s.multiple {
+		message = fmt.Sprintf("[%s] %s", s.name, message)
+	}
+
+	notify(message)
+}
+
+// dir возвращает каталог для файлов кабинета. Если кабинетов несколько, то файлы кабинета хранятся
+// в подкаталоге с его именем
+func (s *seller) dir(base string) string {
+	if !s.multiple {
+		return base
+	}
+
+	return filepath.Join(base, s.name)
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log/slog"
+	"time"
 
-	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
 )
 
 // supplierStock описывает остаток по товару для известного sku
@@ -21,8 +23,8 @@ type supplierStocks struct {
 }
 
 // newSupplierStocks создает спиоск остатков на складах WB.
-func newSupplierStocks(wbClient *wbapi.Client, dateFrom string) (*supplierStocks, error) {
-	wbStocks, err := wbClient.GetStatisticsSupplierStock(dateFrom)
+func newSupplierStocks(s *seller, dateFrom string) (*supplierStocks, error) {
+	wbStocks, err := s.client.GetStatisticsSupplierStock(dateFrom)
 	if err != nil {
 		return nil, err
 	}
@@ -54,10 +56,10 @@ func (m *supplierStocks) count() int {
 }
 
 // getSkusForDelete получает список skus для удаления
-func (m *supplierStocks) getSkusForDelete() ([]string, error) {
+func (m *supplierStocks) getSkusForDelete(p *pClinet) ([]string, error) {
 	var res []string
 
-	skus, err := pdb.getSkusSupplierStocksTable()
+	skus, err := p.getSkusSupplierStocksTable()
 	if err != nil {
 		return []string{}, err
 	}
@@ -71,3 +73,119 @@ func (m *supplierStocks) getSkusForDelete() ([]string, error) {
 	}
 	return res, nil
 }
+
+// statisticsOrdersSync загружает изменения заказов в БД
+func statisticsOrdersSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	dateFrom, err := s.db.getStatisticsDateFrom("wb_orders")
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении даты последнего изменения заказов из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	orders, err := s.client.GetStatisticsOrders(dateFrom, 0)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении заказов произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получено %d заказов, измененных с %s", len(orders), dateFrom))
+
+	if err := s.db.syncStatisticsOrders(orders); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации заказов произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// statisticsSalesSync загружает изменения продаж и возвратов в БД
+func statisticsSalesSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	dateFrom, err := s.db.getStatisticsDateFrom("wb_sales")
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении даты последнего изменения продаж из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	sales, err := s.client.GetStatisticsSales(dateFrom, 0)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении продаж произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получено %d продаж и возвратов, измененных с %s", len(sales), dateFrom))
+
+	if err := s.db.syncStatisticsSales(sales); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации продаж произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// statisticsIncomesSync загружает изменения поставок на склады WB в БД
+func statisticsIncomesSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	dateFrom, err := s.db.getStatisticsDateFrom("wb_incomes")
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении даты последнего изменения поставок из БД произошла ошибка %s", err.Error()))
+		return
+	}
+
+	incomes, err := s.client.GetStatisticsIncomes(dateFrom)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении поставок произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf("Получено %d строк поставок, измененных с %s", len(incomes), dateFrom))
+
+	if err := s.db.syncStatisticsIncomes(incomes); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации поставок произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// lastClosedWeek возвращает начало и конец последней закрытой недели (понедельник - воскресенье)
+func lastClosedWeek(now time.Time) (time.Time, time.Time) {
+	// Количество дней, прошедших с понедельника текущей недели
+	days := (int(now.Weekday()) + 6) % 7
+	monday := now.AddDate(0, 0, -days-7)
+
+	return monday, monday.AddDate(0, 0, 6)
+}
+
+// loadReportDetail загружает детализацию отчетов о реализации за период в БД
+func loadReportDetail(s *seller, dateFrom string, dateTo string) error {
+	rows, err := s.client.GetStatisticsReportDetail(dateFrom, dateTo)
+	if err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Получено %d строк отчетов о реализации с %s по %s", len(rows), dateFrom, dateTo))
+
+	return s.db.syncStatisticsReportDetail(rows)
+}
+
+// statisticsReportSync загружает отчет о реализации за последнюю закрытую неделю
+func statisticsReportSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	from, to := lastClosedWeek(time.Now())
+
+	if err := loadReportDetail(s, from.Format("2006-01-02"), to.Format("2006-01-02")); err != nil {
+		slog.Error(fmt.Sprintf("При загрузке отчета о реализации произошла ошибка %s", err.Error()))
+		return
+	}
+}
+
+// realizationReportCommand загружает отчеты о реализации за указанный период.
+// По умолчанию загружается последняя закрытая неделя
+func realizationReportCommand(s *seller, args []string) error {
+	from, to := lastClosedWeek(time.Now())
+
+	flags := flag.NewFlagSet("realization-report", flag.ContinueOnError)
+	dateFrom := flags.String("from", from.Format("2006-01-02"), "Начало периода")
+	dateTo := flags.String("to", to.Format("2006-01-02"), "Конец периода")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	return loadReportDetail(s, *dateFrom, *dateTo)
+}
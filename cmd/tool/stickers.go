@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+)
+
+// stickerPath возвращает путь к файлу стикера сборочного задания в архиве.
+// Размер входит в имя файла, так как стикеры разных размеров отличаются изображением
+func stickerPath(supplyID string, orderID uint64, stickerType wbapi.StickerType, size wbapi.StickerSize) string {
+	return filepath.Join(
+		config.GetString("marketplace.stickers_dir"), supplyID,
+		fmt.Sprintf("%d.%s.%s", orderID, size, stickerType),
+	)
+}
+
+// archiveSupplyStickers сохраняет стикеры сборочных заданий поставки в архив.
+// Стикеры, которые уже есть в архиве, повторно не запрашиваются.
+// Возвращает содержимое стикеров в порядке сборочных заданий поставки. Задания, для которых WB
+// не вернул стикер (например, отмененные), пропускаются
+func archiveSupplyStickers(s *seller, supplyID string, stickerType wbapi.StickerType, size wbapi.StickerSize) ([][]byte, error) {
+	orders, err := s.client.GetSupplyOrders(supplyID)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d сборочных заданий поставки %s", len(orders), supplyID))
+
+	if err := syncSupply(s, supplyID); err != nil {
+		return nil, err
+	}
+
+	var missing []uint64
+	for _, order := range orders {
+		if err := s.db.upsertSupplyOrder(supplyID, order); err != nil {
+			return nil, err
+		}
+
+		if _, err := os.Stat(stickerPath(supplyID, order.ID, stickerType, size)); errors.Is(err, os.ErrNotExist) {
+			missing = append(missing, order.ID)
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := os.MkdirAll(filepath.Dir(stickerPath(supplyID, 0, stickerType, size)), 0755); err != nil {
+			return nil, err
+		}
+
+		stickers, err := s.client.GetOrderStickers(missing, stickerType, size)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, sticker := range stickers {
+			file, err := base64.StdEncoding.DecodeString(sticker.File)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := os.WriteFile(stickerPath(supplyID, sticker.OrderID, stickerType, size), file, 0644); err != nil {
+				return nil, err
+			}
+
+			if err := s.db.updateSupplyOrderSticker(sticker); err != nil {
+				return nil, err
+			}
+		}
+		slog.Info(fmt.Sprintf("В архив сохранено %d стикеров поставки %s", len(stickers), supplyID))
+	}
+
+	var files [][]byte
+	for _, order := range orders {
+		file, err := os.ReadFile(stickerPath(supplyID, order.ID, stickerType, size))
+		if errors.Is(err, os.ErrNotExist) {
+			slog.Warn(fmt.Sprintf("Стикер сборочного задания %d поставки %s не получен", order.ID, supplyID))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+// supplyStickersCommand сохраняет стикеры поставки в архив и выгружает их одним файлом.
+// Стикеры png объединяются в PDF, стикеры zplv и zplh в один поток ZPL
+func supplyStickersCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("supply-stickers", flag.ContinueOnError)
+	stickerType := flags.String("type", config.GetString("marketplace.stickers_type"), "Формат стикеров: svg, zplv, zplh, png")
+	sizeString := flags.String("size", config.GetString("marketplace.stickers_size"), "Размер стикеров: 58x40, 40x30")
+	out := flags.String("out", "", "Файл для выгрузки стикеров, - для вывода в stdout. По умолчанию <supply_id>.pdf или <supply_id>.zpl")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New("не указан ID поставки")
+	}
+	supplyID := flags.Arg(0)
+
+	size, err := wbapi.ParseStickerSize(*sizeString)
+	if err != nil {
+		return err
+	}
+
+	files, err := archiveSupplyStickers(s, supplyID, wbapi.StickerType(*stickerType), size)
+	if err != nil {
+		return err
+	}
+
+	var ext string
+	switch wbapi.StickerType(*stickerType) {
+	case wbapi.StickerTypePNG:
+		ext = "pdf"
+	case wbapi.StickerTypeZPLV, wbapi.StickerTypeZPLH:
+		ext = "zpl"
+	default:
+		slog.Info(fmt.Sprintf("Стикеры поставки %s сохранены в архив, выгрузка формата %s одним файлом не поддерживается", supplyID, *stickerType))
+		return nil
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		if *out == "" {
+			*out = fmt.Sprintf("%s.%s", supplyID, ext)
+		}
+
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := writeStickers(w, files, ext, size.Width); err != nil {
+		return err
+	}
+
+	if *out != "-" {
+		slog.Info(fmt.Sprintf("%d стикеров поставки %s выгружено в %s", len(files), supplyID, *out))
+	}
+
+	return nil
+}
+
+// writeStickers записывает стикеры в w одним файлом: изображения собираются в PDF шириной width мм,
+// ZPL стикеры записываются подряд
+func writeStickers(w io.Writer, files [][]byte, ext string, width uint32) error {
+	if ext == "pdf" {
+		return writeImagesPDF(w, files, width)
+	}
+
+	for _, file := range files {
+		if _, err := w.Write(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
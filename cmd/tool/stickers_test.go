@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+)
+
+// capture перенаправляет stdout и stderr во время выполнения fn и возвращает их содержимое
+func capture(t *testing.T, fn func()) (stdout []byte, stderr []byte) {
+	t.Helper()
+
+	read := func(f **os.File) (func() []byte, func()) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := *f
+		*f = w
+
+		done := make(chan []byte)
+		go func() {
+			data, _ := io.ReadAll(r)
+			done <- data
+		}()
+
+		return func() []byte { w.Close(); return <-done }, func() { *f = orig }
+	}
+
+	readStdout, restoreStdout := read(&os.Stdout)
+	defer restoreStdout()
+	readStderr, restoreStderr := read(&os.Stderr)
+	defer restoreStderr()
+
+	fn()
+
+	return readStdout(), readStderr()
+}
+
+func TestWriteStickersToStdout(t *testing.T) {
+	files := [][]byte{[]byte("^XA^FO10,10^FD1^FS^XZ\n"), []byte("^XA^FO10,10^FD2^FS^XZ\n")}
+
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+
+	stdout, stderr := capture(t, func() {
+		slog.SetDefault(newLogger(slog.LevelInfo))
+		slog.Info("Получено 2 сборочных заданий поставки WB-GI-1")
+		if err := writeStickers(os.Stdout, files, "zpl", 58); err != nil {
+			t.Error(err)
+		}
+		slog.Info("Стикеры выгружены")
+	})
+
+	if want := bytes.Join(files, nil); !bytes.Equal(stdout, want) {
+		t.Errorf("в stdout записано %q, ожидалось %q", stdout, want)
+	}
+	if !strings.Contains(string(stderr), "Стикеры выгружены") {
+		t.Errorf("логи не записаны в stderr: %q", stderr)
+	}
+}
+
+func TestWriteStickersPDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeStickers(&buf, [][]byte{testPNG(t, 4, 2)}, "pdf", 58); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-1.4\n")) || !bytes.HasSuffix(buf.Bytes(), []byte("%%EOF\n")) {
+		t.Error("в writer записано что-то кроме PDF")
+	}
+}
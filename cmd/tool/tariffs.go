@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// tariffsSync сохраняет снимок тарифов для коробов, монопаллет, возвратов и комиссий по предметам на текущий день
+func tariffsSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	date := time.Now().Format("2006-01-02")
+
+	box, err := s.client.GetBoxTariffs(date)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении тарифов для коробов произошла ошибка %s", err.Error()))
+		return
+	}
+
+	pallet, err := s.client.GetPalletTariffs(date)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении тарифов для монопаллет произошла ошибка %s", err.Error()))
+		return
+	}
+
+	ret, err := s.client.GetReturnTariffs(date)
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении тарифов на возврат произошла ошибка %s", err.Error()))
+		return
+	}
+
+	commissions, err := s.client.GetCommissions()
+	if err != nil {
+		slog.Error(fmt.Sprintf("При получении комиссий произошла ошибка %s", err.Error()))
+		return
+	}
+	slog.Info(fmt.Sprintf(
+		"Получено тарифов для коробов %d, для монопаллет %d, на возврат %d, комиссий %d",
+		len(box), len(pallet), len(ret), len(commissions),
+	))
+
+	if err := s.db.replaceTariffs(date, box, pallet, ret, commissions); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации тарифов произошла ошибка %s", err.Error()))
+		return
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/e-vasilyev/wb-tool/internal/wbapi"
+	"github.com/go-co-op/gocron"
+)
+
+// warehouseConfig описывает склад продавца в файле настроек
+type warehouseConfig struct {
+	Name     string `mapstructure:"name"`
+	OfficeID uint32 `mapstructure:"office_id"`
+}
+
+// syncOfficesAndWarehouses синхронизирует склады WB и склады продавца с БД
+func syncOfficesAndWarehouses(s *seller) ([]*wbapi.Office, []*wbapi.Warehouse, error) {
+	offices, err := s.client.GetOffices()
+	if err != nil {
+		return nil, nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d складов WB", len(offices)))
+
+	if err := s.db.syncOffices(offices); err != nil {
+		return nil, nil, err
+	}
+
+	warehouses, err := s.client.GetWarehouses()
+	if err != nil {
+		return nil, nil, err
+	}
+	slog.Info(fmt.Sprintf("Получено %d складов продавца", len(warehouses)))
+
+	if err := s.db.syncWarehouses(warehouses); err != nil {
+		return nil, nil, err
+	}
+
+	return offices, warehouses, nil
+}
+
+// officesSync синхронизирует склады WB и склады продавца
+func officesSync(s *seller, job gocron.Job) {
+	defer slog.Info(fmt.Sprintf("Следующий запуск задачи '%s' в %s", job.GetName(), job.NextRun()))
+
+	if _, _, err := syncOfficesAndWarehouses(s); err != nil {
+		slog.Error(fmt.Sprintf("При синхронизации складов произошла ошибка %s", err.Error()))
+	}
+}
+
+// officesCommand синхронизирует и выводит список складов WB
+func officesCommand(s *seller, args []string) error {
+	offices, _, err := syncOfficesAndWarehouses(s)
+	if err != nil {
+		return err
+	}
+
+	for _, office := range offices {
+		fmt.Printf("%d\t%s\t%s\t%s\n", office.ID, office.Name, office.City, office.Address)
+	}
+
+	return nil
+}
+
+// warehousesCommand синхронизирует и выводит список складов продавца
+func warehousesCommand(s *seller, args []string) error {
+	_, warehouses, err := syncOfficesAndWarehouses(s)
+	if err != nil {
+		return err
+	}
+
+	for _, warehouse := range warehouses {
+		fmt.Printf("%d\t%s\t%d\n", warehouse.ID, warehouse.Name, warehouse.OfficeID)
+	}
+
+	return nil
+}
+
+// warehousesApplyCommand приводит склады продавца в соответствие с настройкой marketplace.warehouses.
+// Склады сопоставляются по имени. Склады, которых нет в настройке, удаляются только с флагом -prune
+func warehousesApplyCommand(s *seller, args []string) error {
+	flags := flag.NewFlagSet("warehouses-apply", flag.ContinueOnError)
+	prune := flags.Bool("prune", false, "Удалить склады продавца, которых нет в настройках")
+	dryRun := flags.Bool("dry-run", false, "Только вывести изменения")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var configs []warehouseConfig
+	if err := config.UnmarshalKey("marketplace.warehouses", &configs); err != nil {
+		return err
+	}
+
+	if len(configs) == 0 && !*prune {
+		return errors.New("в настройке marketplace.warehouses не указаны склады")
+	}
+
+	warehouses, err := s.client.GetWarehouses()
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]*wbapi.Warehouse, len(warehouses))
+	for _, warehouse := range warehouses {
+		existing[warehouse.Name] = warehouse
+	}
+
+	desired := make(map[string]struct{}, len(configs))
+	for _, wc := range configs {
+		desired[wc.Name] = struct{}{}
+
+		warehouse, ok := existing[wc.Name]
+		switch {
+		case !ok:
+			slog.Info(fmt.Sprintf("Создание склада продавца '%s' на складе WB %d", wc.Name, wc.OfficeID))
+			if *dryRun {
+				continue
+			}
+			id, err := s.client.CreateWarehouse(wc.Name, wc.OfficeID)
+			if err != nil {
+				return err
+			}
+			slog.Info(fmt.Sprintf("Создан склад продавца %d '%s'", id, wc.Name))
+		case warehouse.OfficeID != wc.OfficeID:
+			slog.Info(fmt.Sprintf("Изменение склада продавца %d '%s': склад WB %d -> %d", warehouse.ID, wc.Name, warehouse.OfficeID, wc.OfficeID))
+			if *dryRun {
+				continue
+			}
+			if err := s.client.UpdateWarehouse(warehouse.ID, wc.Name, wc.OfficeID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if *prune {
+		for _, warehouse := range warehouses {
+			if _, ok := desired[warehouse.Name]; ok {
+				continue
+			}
+
+			slog.Info(fmt.Sprintf("Удаление склада продавца %d '%s'", warehouse.ID, warehouse.Name))
+			if *dryRun {
+				continue
+			}
+			if err := s.client.DeleteWarehouse(warehouse.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if *dryRun {
+		return nil
+	}
+
+	_, _, err = syncOfficesAndWarehouses(s)
+
+	return err
+}
+
+// warehouseDeleteCommand удаляет склад продавца
+func warehouseDeleteCommand(s *seller, args []string) error {
+	if len(args) != 1 {
+		return errors.New("не указан ID склада продавца")
+	}
+
+	warehouseID, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.DeleteWarehouse(uint32(warehouseID)); err != nil {
+		return err
+	}
+	slog.Info(fmt.Sprintf("Склад продавца %d удален", warehouseID))
+
+	_, _, err = syncOfficesAndWarehouses(s)
+
+	return err
+}
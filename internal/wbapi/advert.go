@@ -0,0 +1,302 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	advertPathPromotionCount   string = "adv/v1/promotion/count"
+	advertPathPromotionAdverts string = "adv/v1/promotion/adverts"
+	advertPathFullstats        string = "adv/v2/fullstats"
+	advertPathBalance          string = "adv/v1/balance"
+	advertPathBudget           string = "adv/v1/budget"
+	advertPathPause            string = "adv/v0/pause"
+	advertPathStart            string = "adv/v0/start"
+	advertAdvertsLimit         int    = 50
+	advertFullstatsLimit       int    = 100
+)
+
+// Статусы рекламных кампаний
+const (
+	AdvertStatusDeleting int8 = -1
+	AdvertStatusReady    int8 = 4
+	AdvertStatusFinished int8 = 7
+	AdvertStatusRefused  int8 = 8
+	AdvertStatusActive   int8 = 9
+	AdvertStatusPaused   int8 = 11
+)
+
+// advertRateLimit ограничение количества отправленных запросов в минуту.
+// 5 запросов в секунду к api продвижения
+var advertRateLimit *rateLimit = &rateLimit{interval: time.Millisecond * 250}
+
+// advertFullstatsRateLimit ограничение количества отправленных запросов в минуту.
+// 1 запрос в минуту к статистике кампаний
+var advertFullstatsRateLimit *rateLimit = &rateLimit{interval: time.Minute}
+
+// AdvertListItem описывает кампанию в списке кампаний
+type AdvertListItem struct {
+	AdvertID   uint32 `json:"advertId"`
+	ChangeTime string `json:"changeTime"`
+}
+
+// advertsCount описывает ответ со списком кампаний, сгруппированных по типу и статусу
+type advertsCount struct {
+	Adverts []struct {
+		Type       uint8             `json:"type"`
+		Status     int8              `json:"status"`
+		Count      uint32            `json:"count"`
+		AdvertList []*AdvertListItem `json:"advert_list"`
+	} `json:"adverts"`
+	All uint32 `json:"all"`
+}
+
+// AdvertNm описывает карточку в параметрах кампании
+type AdvertNm struct {
+	Nm     uint32 `json:"nm"`
+	Active bool   `json:"active"`
+}
+
+// Advert описывает рекламную кампанию.
+// Карточки кампании в зависимости от ее типа находятся в Params, AutoParams или UnitedParams
+type Advert struct {
+	AdvertID    uint32  `json:"advertId"`
+	Name        string  `json:"name"`
+	Type        uint8   `json:"type"`
+	Status      int8    `json:"status"`
+	DailyBudget float64 `json:"dailyBudget"`
+	PaymentType string  `json:"paymentType"`
+	CreateTime  string  `json:"createTime"`
+	ChangeTime  string  `json:"changeTime"`
+	StartTime   string  `json:"startTime"`
+	EndTime     string  `json:"endTime"`
+	Params      []struct {
+		Nms []*AdvertNm `json:"nms"`
+	} `json:"params"`
+	AutoParams *struct {
+		Nms []uint32 `json:"nms"`
+	} `json:"autoParams"`
+	UnitedParams []struct {
+		Nms []uint32 `json:"nms"`
+	} `json:"unitedParams"`
+}
+
+// NmIDs возвращает карточки, которые рекламируются в кампании
+func (a *Advert) NmIDs() []uint32 {
+	var nmIDs []uint32
+
+	seen := make(map[uint32]bool)
+	add := func(nmID uint32) {
+		if !seen[nmID] {
+			seen[nmID] = true
+			nmIDs = append(nmIDs, nmID)
+		}
+	}
+
+	for _, p := range a.Params {
+		for _, nm := range p.Nms {
+			add(nm.Nm)
+		}
+	}
+	if a.AutoParams != nil {
+		for _, nmID := range a.AutoParams.Nms {
+			add(nmID)
+		}
+	}
+	for _, p := range a.UnitedParams {
+		for _, nmID := range p.Nms {
+			add(nmID)
+		}
+	}
+
+	return nmIDs
+}
+
+// AdvertStatsValues описывает показатели кампании
+type AdvertStatsValues struct {
+	Views    uint32  `json:"views"`
+	Clicks   uint32  `json:"clicks"`
+	Sum      float64 `json:"sum"`
+	Atbs     uint32  `json:"atbs"`
+	Orders   uint32  `json:"orders"`
+	Shks     uint32  `json:"shks"`
+	SumPrice float64 `json:"sum_price"`
+}
+
+// AdvertStatsNm описывает показатели кампании по карточке
+type AdvertStatsNm struct {
+	AdvertStatsValues
+	NmID uint32 `json:"nmId"`
+	Name string `json:"name"`
+}
+
+// AdvertStatsDay описывает показатели кампании за день по платформам
+type AdvertStatsDay struct {
+	AdvertStatsValues
+	Date string `json:"date"`
+	Apps []struct {
+		AppType uint8            `json:"appType"`
+		Nm      []*AdvertStatsNm `json:"nm"`
+	} `json:"apps"`
+}
+
+// AdvertStats описывает статистику кампании по дням
+type AdvertStats struct {
+	AdvertID uint32            `json:"advertId"`
+	Days     []*AdvertStatsDay `json:"days"`
+}
+
+// AdvertBalance описывает счет продвижения
+type AdvertBalance struct {
+	Balance float64 `json:"balance"`
+	Net     float64 `json:"net"`
+	Bonus   float64 `json:"bonus"`
+}
+
+// AdvertBudget описывает бюджет кампании
+type AdvertBudget struct {
+	Cash    float64 `json:"cash"`
+	Netting float64 `json:"netting"`
+	Total   float64 `json:"total"`
+}
+
+// fullstatsRequest описывает кампанию и период в запросе статистики
+type fullstatsRequest struct {
+	ID       uint32 `json:"id"`
+	Interval struct {
+		Begin string `json:"begin"`
+		End   string `json:"end"`
+	} `json:"interval"`
+}
+
+// GetAdvertList возвращает список всех кампаний продавца
+func (c *Client) GetAdvertList() ([]*AdvertListItem, error) {
+	c.logger.Debug("Получение списка рекламных кампаний")
+
+	count := &advertsCount{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.advert, advertPathPromotionCount)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, count, advertRateLimit); err != nil {
+		return nil, err
+	}
+
+	var adverts []*AdvertListItem
+	for _, group := range count.Adverts {
+		adverts = append(adverts, group.AdvertList...)
+	}
+
+	return adverts, nil
+}
+
+// GetAdverts возвращает информацию о кампаниях.
+// Информация запрашивается по 50 кампаний
+func (c *Client) GetAdverts(advertIDs []uint32) ([]*Advert, error) {
+	c.logger.Debug(fmt.Sprintf("Получение информации о %d рекламных кампаниях", len(advertIDs)))
+
+	var adverts []*Advert
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.advert, advertPathPromotionAdverts)
+
+	for i := 0; i < len(advertIDs); i += advertAdvertsLimit {
+		chunk := advertIDs[i:min(i+advertAdvertsLimit, len(advertIDs))]
+
+		var page []*Advert
+		if err := c.requestJSON(http.MethodPost, uri, chunk, &page, advertRateLimit); err != nil {
+			return nil, err
+		}
+
+		adverts = append(adverts, page...)
+	}
+
+	return adverts, nil
+}
+
+// GetAdvertStats возвращает статистику кампаний по дням и карточкам за период.
+// Статистика запрашивается по 100 кампаний
+func (c *Client) GetAdvertStats(advertIDs []uint32, begin string, end string) ([]*AdvertStats, error) {
+	c.logger.Debug(fmt.Sprintf("Получение статистики %d рекламных кампаний с %s по %s", len(advertIDs), begin, end))
+
+	var stats []*AdvertStats
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.advert, advertPathFullstats)
+
+	for i := 0; i < len(advertIDs); i += advertFullstatsLimit {
+		var body []*fullstatsRequest
+		for _, advertID := range advertIDs[i:min(i+advertFullstatsLimit, len(advertIDs))] {
+			r := &fullstatsRequest{ID: advertID}
+			r.Interval.Begin = begin
+			r.Interval.End = end
+			body = append(body, r)
+		}
+
+		var page []*AdvertStats
+		if err := c.requestJSON(http.MethodPost, uri, body, &page, advertFullstatsRateLimit); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, page...)
+	}
+
+	return stats, nil
+}
+
+// GetAdvertBalance возвращает баланс счета продвижения
+func (c *Client) GetAdvertBalance() (*AdvertBalance, error) {
+	c.logger.Debug("Получение баланса счета продвижения")
+
+	balance := &AdvertBalance{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.advert, advertPathBalance)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, balance, advertRateLimit); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
+
+// GetAdvertBudget возвращает бюджет кампании
+func (c *Client) GetAdvertBudget(advertID uint32) (*AdvertBudget, error) {
+	c.logger.Debug(fmt.Sprintf("Получение бюджета рекламной кампании %d", advertID))
+
+	budget := &AdvertBudget{}
+
+	query := url.Values{}
+	query.Set("id", fmt.Sprintf("%d", advertID))
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.advert, advertPathBudget, query.Encode())
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, budget, advertRateLimit); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// PauseAdvert приостанавливает кампанию
+func (c *Client) PauseAdvert(advertID uint32) error {
+	c.logger.Debug(fmt.Sprintf("Приостановка рекламной кампании %d", advertID))
+
+	return c.advertAction(advertPathPause, advertID)
+}
+
+// StartAdvert запускает кампанию
+func (c *Client) StartAdvert(advertID uint32) error {
+	c.logger.Debug(fmt.Sprintf("Запуск рекламной кампании %d", advertID))
+
+	return c.advertAction(advertPathStart, advertID)
+}
+
+// advertAction выполняет действие над кампанией
+func (c *Client) advertAction(path string, advertID uint32) error {
+	query := url.Values{}
+	query.Set("id", fmt.Sprintf("%d", advertID))
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.advert, path, query.Encode())
+
+	return c.requestJSON(http.MethodGet, uri, nil, nil, advertRateLimit)
+}
@@ -0,0 +1,139 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	analyticsPathPaidStorage       string        = "api/v1/paid_storage"
+	analyticsPathAcceptanceReport  string        = "api/v1/acceptance_report"
+	analyticsAsyncReportMaxWait    time.Duration = 30 * time.Minute
+	analyticsAsyncReportStatusDone string        = "done"
+)
+
+// analyticsRateLimit ограничение количества отправленных запросов в минуту.
+// Отчеты аналитики имеют жесткие лимиты, поэтому не чаще раза в 5 секунд
+var analyticsRateLimit *rateLimit = &rateLimit{interval: time.Second * 5}
+
+// asyncReportTask описывает ответ на создание задания на формирование отчета
+type asyncReportTask struct {
+	Data struct {
+		TaskID string `json:"taskId"`
+	} `json:"data"`
+}
+
+// asyncReportStatus описывает ответ на проверку статуса задания
+type asyncReportStatus struct {
+	Data struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	} `json:"data"`
+}
+
+// PaidStorage описывает стоимость платного хранения баркода на складе за день
+type PaidStorage struct {
+	Date             string  `json:"date"`
+	LogWarehouseCoef float64 `json:"logWarehouseCoef"`
+	OfficeID         uint32  `json:"officeId"`
+	Warehouse        string  `json:"warehouse"`
+	WarehouseCoef    float64 `json:"warehouseCoef"`
+	GiID             uint64  `json:"giId"`
+	ChrtID           uint64  `json:"chrtId"`
+	Size             string  `json:"size"`
+	Barcode          string  `json:"barcode"`
+	Subject          string  `json:"subject"`
+	Brand            string  `json:"brand"`
+	VendorCode       string  `json:"vendorCode"`
+	NmID             uint32  `json:"nmId"`
+	Volume           float64 `json:"volume"`
+	CalcType         string  `json:"calcType"`
+	WarehousePrice   float64 `json:"warehousePrice"`
+	BarcodesCount    uint32  `json:"barcodesCount"`
+	PalletPlaceCode  uint32  `json:"palletPlaceCode"`
+	PalletCount      float64 `json:"palletCount"`
+	LoyaltyDiscount  float64 `json:"loyaltyDiscount"`
+}
+
+// PaidAcceptance описывает стоимость платной приемки товара
+type PaidAcceptance struct {
+	Count         uint32  `json:"count"`
+	GiCreateDate  string  `json:"giCreateDate"`
+	IncomeID      uint64  `json:"incomeId"`
+	NmID          uint32  `json:"nmID"`
+	ShkCreateDate string  `json:"shkCreateDate"`
+	SubjectName   string  `json:"subjectName"`
+	Total         float64 `json:"total"`
+}
+
+// GetPaidStorage возвращает отчет о платном хранении за период.
+// Период не должен превышать 8 дней
+func (c *Client) GetPaidStorage(dateFrom string, dateTo string) ([]*PaidStorage, error) {
+	c.logger.Debug(fmt.Sprintf("Получение отчета о платном хранении с %s по %s", dateFrom, dateTo))
+
+	query := url.Values{}
+	query.Set("dateFrom", dateFrom)
+	query.Set("dateTo", dateTo)
+
+	return getAsyncReport[PaidStorage](c, analyticsPathPaidStorage, query)
+}
+
+// GetPaidAcceptance возвращает отчет о платной приемке за период.
+// Период не должен превышать 31 день
+func (c *Client) GetPaidAcceptance(dateFrom string, dateTo string) ([]*PaidAcceptance, error) {
+	c.logger.Debug(fmt.Sprintf("Получение отчета о платной приемке с %s по %s", dateFrom, dateTo))
+
+	query := url.Values{}
+	query.Set("dateFrom", dateFrom)
+	query.Set("dateTo", dateTo)
+
+	return getAsyncReport[PaidAcceptance](c, analyticsPathAcceptanceReport, query)
+}
+
+// getAsyncReport получает отчет, который формируется асинхронно.
+// Создается задание на формирование отчета, затем проверяется его статус,
+// после завершения задания отчет загружается
+func getAsyncReport[T any](c *Client, path string, query url.Values) ([]*T, error) {
+	task := &asyncReportTask{}
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.analytics, path, query.Encode())
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, task, analyticsRateLimit); err != nil {
+		return nil, err
+	}
+	c.logger.Debug(fmt.Sprintf("Создано задание %s на формирование отчета %s", task.Data.TaskID, path))
+
+	deadline := time.Now().Add(analyticsAsyncReportMaxWait)
+	uri = fmt.Sprintf("%s/%s/tasks/%s/status", c.baseURL.analytics, path, task.Data.TaskID)
+
+	for {
+		status := &asyncReportStatus{}
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, status, analyticsRateLimit); err != nil {
+			return nil, err
+		}
+
+		switch status.Data.Status {
+		case analyticsAsyncReportStatusDone:
+			var result []*T
+
+			uri = fmt.Sprintf("%s/%s/tasks/%s/download", c.baseURL.analytics, path, task.Data.TaskID)
+
+			if err := c.requestJSON(http.MethodGet, uri, nil, &result, analyticsRateLimit); err != nil {
+				return nil, err
+			}
+
+			return result, nil
+		case "new", "processing":
+			c.logger.Debug(fmt.Sprintf("Задание %s в статусе %s", task.Data.TaskID, status.Data.Status))
+		default:
+			return nil, fmt.Errorf("задание %s на формирование отчета %s завершено со статусом %s", task.Data.TaskID, path, status.Data.Status)
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("задание %s на формирование отчета %s не выполнено за %s", task.Data.TaskID, path, analyticsAsyncReportMaxWait)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	analyticsPathNmReportDetail        string = "api/v2/nm-report/detail"
+	analyticsPathNmReportDetailHistory string = "api/v2/nm-report/detail/history"
+	analyticsNmReportHistoryLimit      int    = 20
+	analyticsNmReportTimezone          string = "Europe/Moscow"
+)
+
+// nmReportRateLimit ограничение количества отправленных запросов в минуту.
+// 3 запроса в минуту к воронке продаж (раз в 20 секунд)
+var nmReportRateLimit *rateLimit = &rateLimit{interval: time.Second * 20}
+
+// NmReportPeriod описывает период отчета
+type NmReportPeriod struct {
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+}
+
+// NmReportConversions описывает конверсии карточки за период
+type NmReportConversions struct {
+	AddToCartPercent   float64 `json:"addToCartPercent"`
+	CartToOrderPercent float64 `json:"cartToOrderPercent"`
+	BuyoutsPercent     float64 `json:"buyoutsPercent"`
+}
+
+// NmReportStatistics описывает показатели воронки продаж карточки за период
+type NmReportStatistics struct {
+	Begin                string              `json:"begin"`
+	End                  string              `json:"end"`
+	OpenCardCount        uint32              `json:"openCardCount"`
+	AddToCartCount       uint32              `json:"addToCartCount"`
+	OrdersCount          uint32              `json:"ordersCount"`
+	OrdersSumRub         float64             `json:"ordersSumRub"`
+	BuyoutsCount         uint32              `json:"buyoutsCount"`
+	BuyoutsSumRub        float64             `json:"buyoutsSumRub"`
+	CancelCount          uint32              `json:"cancelCount"`
+	CancelSumRub         float64             `json:"cancelSumRub"`
+	AvgPriceRub          float64             `json:"avgPriceRub"`
+	AvgOrdersCountPerDay float64             `json:"avgOrdersCountPerDay"`
+	Conversions          NmReportConversions `json:"conversions"`
+}
+
+// NmReportCard описывает карточку в отчете воронки продаж
+type NmReportCard struct {
+	NmID       uint32 `json:"nmID"`
+	VendorCode string `json:"vendorCode"`
+	BrandName  string `json:"brandName"`
+	Statistics struct {
+		SelectedPeriod NmReportStatistics `json:"selectedPeriod"`
+	} `json:"statistics"`
+}
+
+// NmReportHistoryDay описывает показатели воронки продаж карточки за день
+type NmReportHistoryDay struct {
+	Dt                    string  `json:"dt"`
+	OpenCardCount         uint32  `json:"openCardCount"`
+	AddToCartCount        uint32  `json:"addToCartCount"`
+	AddToCartConversion   float64 `json:"addToCartConversion"`
+	OrdersCount           uint32  `json:"ordersCount"`
+	OrdersSumRub          float64 `json:"ordersSumRub"`
+	CartToOrderConversion float64 `json:"cartToOrderConversion"`
+	BuyoutsCount          uint32  `json:"buyoutsCount"`
+	BuyoutsSumRub         float64 `json:"buyoutsSumRub"`
+	BuyoutPercent         float64 `json:"buyoutPercent"`
+}
+
+// NmReportHistory описывает показатели воронки продаж карточки по дням
+type NmReportHistory struct {
+	NmID       uint32                `json:"nmID"`
+	ImtName    string                `json:"imtName"`
+	VendorCode string                `json:"vendorCode"`
+	History    []*NmReportHistoryDay `json:"history"`
+}
+
+// nmReportDetailRequest описывает тело запроса отчета по карточкам за период
+type nmReportDetailRequest struct {
+	Timezone string         `json:"timezone"`
+	Period   NmReportPeriod `json:"period"`
+	Page     uint32         `json:"page"`
+}
+
+// nmReportDetailResponse описывает ответ отчета по карточкам за период
+type nmReportDetailResponse struct {
+	Data struct {
+		Page       uint32          `json:"page"`
+		IsNextPage bool            `json:"isNextPage"`
+		Cards      []*NmReportCard `json:"cards"`
+	} `json:"data"`
+}
+
+// nmReportHistoryRequest описывает тело запроса отчета по карточкам по дням
+type nmReportHistoryRequest struct {
+	NmIDs            []uint32       `json:"nmIDs"`
+	Period           NmReportPeriod `json:"period"`
+	Timezone         string         `json:"timezone"`
+	AggregationLevel string         `json:"aggregationLevel"`
+}
+
+// nmReportHistoryResponse описывает ответ отчета по карточкам по дням
+type nmReportHistoryResponse struct {
+	Data []*NmReportHistory `json:"data"`
+}
+
+// GetNmReportDetail возвращает показатели воронки продаж всех карточек за период.
+// Даты периода указываются в формате 2006-01-02 15:04:05.
+// Так как получить за раз можно не все карточки, выполняются несколько запросов
+func (c *Client) GetNmReportDetail(begin string, end string) ([]*NmReportCard, error) {
+	c.logger.Debug(fmt.Sprintf("Получение воронки продаж по карточкам с %s по %s", begin, end))
+
+	var cards []*NmReportCard
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.analytics, analyticsPathNmReportDetail)
+
+	body := &nmReportDetailRequest{
+		Timezone: analyticsNmReportTimezone,
+		Period:   NmReportPeriod{Begin: begin, End: end},
+		Page:     1,
+	}
+
+	for {
+		page := &nmReportDetailResponse{}
+
+		if err := c.requestJSON(http.MethodPost, uri, body, page, nmReportRateLimit); err != nil {
+			return nil, err
+		}
+
+		cards = append(cards, page.Data.Cards...)
+
+		if !page.Data.IsNextPage {
+			break
+		}
+		body.Page++
+	}
+
+	return cards, nil
+}
+
+// GetNmReportHistory возвращает показатели воронки продаж карточек по дням.
+// Даты периода указываются в формате 2006-01-02,
+// можно передать массив больше 20, в этом случае запросы разделятся на части
+func (c *Client) GetNmReportHistory(nmIDs []uint32, begin string, end string) ([]*NmReportHistory, error) {
+	c.logger.Debug(fmt.Sprintf("Получение воронки продаж по дням для %d карточек с %s по %s", len(nmIDs), begin, end))
+
+	var history []*NmReportHistory
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.analytics, analyticsPathNmReportDetailHistory)
+
+	for start := 0; start < len(nmIDs); start += analyticsNmReportHistoryLimit {
+		stop := start + analyticsNmReportHistoryLimit
+		if stop > len(nmIDs) {
+			stop = len(nmIDs)
+		}
+
+		page := &nmReportHistoryResponse{}
+		body := &nmReportHistoryRequest{
+			NmIDs:            nmIDs[start:stop],
+			Period:           NmReportPeriod{Begin: begin, End: end},
+			Timezone:         analyticsNmReportTimezone,
+			AggregationLevel: "day",
+		}
+
+		if err := c.requestJSON(http.MethodPost, uri, body, page, nmReportRateLimit); err != nil {
+			return nil, err
+		}
+
+		history = append(history, page.Data...)
+	}
+
+	return history, nil
+}
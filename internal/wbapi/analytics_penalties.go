@@ -0,0 +1,125 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	analyticsPathAntifraudDetails      string = "api/v1/analytics/antifraud-details"
+	analyticsPathGoodsLabeling         string = "api/v1/analytics/goods-labeling"
+	analyticsPathIncorrectAttachments  string = "api/v1/analytics/incorrect-attachments"
+	analyticsPathCharacteristicsChange string = "api/v1/analytics/characteristics-change"
+)
+
+// penaltiesRateLimit ограничение количества отправленных запросов в минуту.
+// 10 запросов в минуту к отчетам об удержаниях
+var penaltiesRateLimit *rateLimit = &rateLimit{interval: time.Second * 6}
+
+// antifraudRateLimit ограничение количества отправленных запросов в минуту.
+// 1 запрос в минуту к отчету о самовыкупах
+var antifraudRateLimit *rateLimit = &rateLimit{interval: time.Minute}
+
+// AntifraudDetail описывает удержание за самовыкуп по карточке за неделю
+type AntifraudDetail struct {
+	NmID     uint32  `json:"nmID"`
+	Sum      float64 `json:"sum"`
+	Currency string  `json:"currency"`
+	DateFrom string  `json:"dateFrom"`
+	DateTo   string  `json:"dateTo"`
+}
+
+// GoodsLabelingFine описывает штраф за отсутствие обязательной маркировки товара
+type GoodsLabelingFine struct {
+	Amount    float64  `json:"amount"`
+	Date      string   `json:"date"`
+	IncomeID  uint32   `json:"incomeId"`
+	NmID      uint32   `json:"nmID"`
+	PhotoURLs []string `json:"photoUrls"`
+	ShkID     uint64   `json:"shkID"`
+	Sku       string   `json:"sku"`
+}
+
+// IncorrectAttachmentFine описывает удержание за подмену товара
+type IncorrectAttachmentFine struct {
+	Amount     float64  `json:"amount"`
+	Date       string   `json:"date"`
+	LostReason string   `json:"lostReason"`
+	NmID       uint32   `json:"nmID"`
+	PhotoURLs  []string `json:"photoUrls"`
+	ShkID      uint64   `json:"shkID"`
+}
+
+// CharacteristicsChangeFine описывает штраф за смену характеристик карточки
+type CharacteristicsChangeFine struct {
+	Amount  float64 `json:"amount"`
+	Date    string  `json:"date"`
+	NmID    uint32  `json:"nmID"`
+	NewChID uint64  `json:"newChID"`
+	OldChID uint64  `json:"oldChID"`
+}
+
+// antifraudResponse описывает ответ отчета о самовыкупах
+type antifraudResponse struct {
+	Details []*AntifraudDetail `json:"details"`
+}
+
+// penaltiesResponse описывает ответ отчета об удержаниях
+type penaltiesResponse[T any] struct {
+	Report []*T `json:"report"`
+}
+
+// GetAntifraudDetails возвращает удержания за самовыкупы за последнюю неделю
+func (c *Client) GetAntifraudDetails() ([]*AntifraudDetail, error) {
+	c.logger.Debug("Получение удержаний за самовыкупы")
+
+	details := &antifraudResponse{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.analytics, analyticsPathAntifraudDetails)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, details, antifraudRateLimit); err != nil {
+		return nil, err
+	}
+
+	return details.Details, nil
+}
+
+// GetGoodsLabelingFines возвращает штрафы за отсутствие маркировки за период в формате YYYY-MM-DD
+func (c *Client) GetGoodsLabelingFines(dateFrom string, dateTo string) ([]*GoodsLabelingFine, error) {
+	c.logger.Debug(fmt.Sprintf("Получение штрафов за отсутствие маркировки с %s по %s", dateFrom, dateTo))
+
+	return getPenalties[GoodsLabelingFine](c, analyticsPathGoodsLabeling, dateFrom, dateTo)
+}
+
+// GetIncorrectAttachmentFines возвращает удержания за подмену товара за период в формате YYYY-MM-DD
+func (c *Client) GetIncorrectAttachmentFines(dateFrom string, dateTo string) ([]*IncorrectAttachmentFine, error) {
+	c.logger.Debug(fmt.Sprintf("Получение удержаний за подмену товара с %s по %s", dateFrom, dateTo))
+
+	return getPenalties[IncorrectAttachmentFine](c, analyticsPathIncorrectAttachments, dateFrom, dateTo)
+}
+
+// GetCharacteristicsChangeFines возвращает штрафы за смену характеристик за период в формате YYYY-MM-DD
+func (c *Client) GetCharacteristicsChangeFines(dateFrom string, dateTo string) ([]*CharacteristicsChangeFine, error) {
+	c.logger.Debug(fmt.Sprintf("Получение штрафов за смену характеристик с %s по %s", dateFrom, dateTo))
+
+	return getPenalties[CharacteristicsChangeFine](c, analyticsPathCharacteristicsChange, dateFrom, dateTo)
+}
+
+// getPenalties возвращает отчет об удержаниях за период
+func getPenalties[T any](c *Client, path string, dateFrom string, dateTo string) ([]*T, error) {
+	penalties := &penaltiesResponse[T]{}
+
+	query := url.Values{}
+	query.Set("dateFrom", dateFrom)
+	query.Set("dateTo", dateTo)
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.analytics, path, query.Encode())
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, penalties, penaltiesRateLimit); err != nil {
+		return nil, err
+	}
+
+	return penalties.Report, nil
+}
@@ -0,0 +1,97 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	analyticsPathSearchTexts      string = "api/v2/search-report/product/search-texts"
+	analyticsSearchTextsNmIDLimit int    = 50
+)
+
+// searchReportRateLimit ограничение количества отправленных запросов в минуту.
+// 3 запроса в минуту к поисковым запросам (раз в 20 секунд)
+var searchReportRateLimit *rateLimit = &rateLimit{interval: time.Second * 20}
+
+// SearchTextMetric описывает значение показателя поискового запроса за период
+type SearchTextMetric struct {
+	Current  float64 `json:"current"`
+	Dynamics float64 `json:"dynamics"`
+}
+
+// SearchText описывает поисковый запрос, по которому покупатели находили карточку
+type SearchText struct {
+	Text           string           `json:"text"`
+	NmID           uint32           `json:"nmId"`
+	Frequency      SearchTextMetric `json:"frequency"`
+	WeekFrequency  uint32           `json:"weekFrequency"`
+	AvgPosition    SearchTextMetric `json:"avgPosition"`
+	MedianPosition SearchTextMetric `json:"medianPosition"`
+	OpenCard       SearchTextMetric `json:"openCard"`
+	AddToCart      SearchTextMetric `json:"addToCart"`
+	Orders         SearchTextMetric `json:"orders"`
+	Visibility     SearchTextMetric `json:"visibility"`
+}
+
+// searchTextsPeriod описывает период отчета по поисковым запросам
+type searchTextsPeriod struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// searchTextsRequest описывает тело запроса поисковых запросов по карточкам
+type searchTextsRequest struct {
+	CurrentPeriod searchTextsPeriod `json:"currentPeriod"`
+	NmIDs         []uint32          `json:"nmIds"`
+	TopOrderBy    string            `json:"topOrderBy"`
+	OrderBy       struct {
+		Field string `json:"field"`
+		Mode  string `json:"mode"`
+	} `json:"orderBy"`
+	Limit uint32 `json:"limit"`
+}
+
+// searchTextsResponse описывает ответ с поисковыми запросами по карточкам
+type searchTextsResponse struct {
+	Data struct {
+		Items []*SearchText `json:"items"`
+	} `json:"data"`
+}
+
+// GetSearchTexts возвращает топ поисковых запросов по карточкам за период.
+// limit ограничивает количество запросов на карточку,
+// можно передать массив nmIDs больше 50, в этом случае запросы разделятся на части
+func (c *Client) GetSearchTexts(nmIDs []uint32, start string, end string, limit uint32) ([]*SearchText, error) {
+	c.logger.Debug(fmt.Sprintf("Получение поисковых запросов для %d карточек с %s по %s", len(nmIDs), start, end))
+
+	var texts []*SearchText
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.analytics, analyticsPathSearchTexts)
+
+	for first := 0; first < len(nmIDs); first += analyticsSearchTextsNmIDLimit {
+		last := first + analyticsSearchTextsNmIDLimit
+		if last > len(nmIDs) {
+			last = len(nmIDs)
+		}
+
+		page := &searchTextsResponse{}
+		body := &searchTextsRequest{
+			CurrentPeriod: searchTextsPeriod{Start: start, End: end},
+			NmIDs:         nmIDs[first:last],
+			TopOrderBy:    "openCard",
+			Limit:         limit,
+		}
+		body.OrderBy.Field = "avgPosition"
+		body.OrderBy.Mode = "asc"
+
+		if err := c.requestJSON(http.MethodPost, uri, body, page, searchReportRateLimit); err != nil {
+			return nil, err
+		}
+
+		texts = append(texts, page.Data.Items...)
+	}
+
+	return texts, nil
+}
@@ -0,0 +1,210 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	calendarPathPromotions        string = "api/v1/calendar/promotions"
+	calendarPathPromotionDetails  string = "api/v1/calendar/promotions/details"
+	calendarPathNomenclatures     string = "api/v1/calendar/promotions/nomenclatures"
+	calendarPathUpload            string = "api/v1/calendar/promotions/upload"
+	calendarPromotionsLimit       uint   = 1000
+	calendarNomenclaturesLimit    uint   = 1000
+	calendarPromotionDetailsLimit int    = 100
+	calendarUploadLimit           int    = 1000
+	PromotionTypeAuto             string = "auto"
+)
+
+// calendarRateLimit ограничение количества отправленных запросов в минуту.
+// 10 запросов за 6 секунд к календарю акций
+var calendarRateLimit *rateLimit = &rateLimit{interval: time.Millisecond * 600}
+
+// Promotion описывает акцию в календаре
+type Promotion struct {
+	ID                        uint32   `json:"id"`
+	Name                      string   `json:"name"`
+	Description               string   `json:"description"`
+	Advantages                []string `json:"advantages"`
+	StartDateTime             string   `json:"startDateTime"`
+	EndDateTime               string   `json:"endDateTime"`
+	Type                      string   `json:"type"`
+	InPromoActionLeftovers    uint32   `json:"inPromoActionLeftovers"`
+	InPromoActionTotal        uint32   `json:"inPromoActionTotal"`
+	NotInPromoActionLeftovers uint32   `json:"notInPromoActionLeftovers"`
+	NotInPromoActionTotal     uint32   `json:"notInPromoActionTotal"`
+	ParticipationPercentage   float64  `json:"participationPercentage"`
+}
+
+// PromotionNomenclature описывает карточку, которая может участвовать в акции
+type PromotionNomenclature struct {
+	ID           uint32  `json:"id"`
+	InAction     bool    `json:"inAction"`
+	Price        float64 `json:"price"`
+	CurrencyCode string  `json:"currencyCode"`
+	PlanPrice    float64 `json:"planPrice"`
+	Discount     float64 `json:"discount"`
+	PlanDiscount float64 `json:"planDiscount"`
+}
+
+// promotionsResponse описывает ответ со списком акций
+type promotionsResponse struct {
+	Data struct {
+		Promotions []*Promotion `json:"promotions"`
+	} `json:"data"`
+}
+
+// nomenclaturesResponse описывает ответ со списком карточек акции
+type nomenclaturesResponse struct {
+	Data struct {
+		Nomenclatures []*PromotionNomenclature `json:"nomenclatures"`
+	} `json:"data"`
+}
+
+// PromotionUpload описывает загрузку карточек в акцию
+type PromotionUpload struct {
+	UploadID uint64
+	NmIDs    []uint32
+}
+
+// promotionUploadRequest описывает тело запроса добавления карточек в акцию
+type promotionUploadRequest struct {
+	Data struct {
+		PromotionID   uint32   `json:"promotionID"`
+		UploadNow     bool     `json:"uploadNow"`
+		Nomenclatures []uint32 `json:"nomenclatures"`
+	} `json:"data"`
+}
+
+// promotionUploadResponse описывает ответ на добавление карточек в акцию
+type promotionUploadResponse struct {
+	Data struct {
+		AlreadyExists bool   `json:"alreadyExists"`
+		UploadID      uint64 `json:"uploadID"`
+	} `json:"data"`
+}
+
+// GetPromotions возвращает акции, которые проходят в период с start по end
+func (c *Client) GetPromotions(start time.Time, end time.Time) ([]*Promotion, error) {
+	c.logger.Debug(fmt.Sprintf("Получение акций с %s по %s", start.Format(time.DateOnly), end.Format(time.DateOnly)))
+
+	var promotions []*Promotion
+
+	for offset := uint(0); ; offset += calendarPromotionsLimit {
+		page := &promotionsResponse{}
+
+		query := url.Values{}
+		query.Set("startDateTime", start.UTC().Format("2006-01-02T15:04:05Z"))
+		query.Set("endDateTime", end.UTC().Format("2006-01-02T15:04:05Z"))
+		query.Set("allPromo", "true")
+		query.Set("limit", fmt.Sprintf("%d", calendarPromotionsLimit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.calendar, calendarPathPromotions, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, calendarRateLimit); err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, page.Data.Promotions...)
+
+		if uint(len(page.Data.Promotions)) < calendarPromotionsLimit {
+			break
+		}
+	}
+
+	return promotions, nil
+}
+
+// GetPromotionDetails возвращает подробную информацию об акциях.
+// Информация запрашивается по 100 акций
+func (c *Client) GetPromotionDetails(promotionIDs []uint32) ([]*Promotion, error) {
+	c.logger.Debug(fmt.Sprintf("Получение информации о %d акциях", len(promotionIDs)))
+
+	var promotions []*Promotion
+
+	for i := 0; i < len(promotionIDs); i += calendarPromotionDetailsLimit {
+		page := &promotionsResponse{}
+
+		query := url.Values{}
+		for _, id := range promotionIDs[i:min(i+calendarPromotionDetailsLimit, len(promotionIDs))] {
+			query.Add("promotionIDs", fmt.Sprintf("%d", id))
+		}
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.calendar, calendarPathPromotionDetails, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, calendarRateLimit); err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, page.Data.Promotions...)
+	}
+
+	return promotions, nil
+}
+
+// GetPromotionNomenclatures возвращает карточки, которые могут участвовать в акции.
+// inAction определяет нужно получить карточки уже участвующие в акции или нет.
+// Для автоакций список недоступен
+func (c *Client) GetPromotionNomenclatures(promotionID uint32, inAction bool) ([]*PromotionNomenclature, error) {
+	c.logger.Debug(fmt.Sprintf("Получение карточек акции %d, участвуют: %t", promotionID, inAction))
+
+	var nomenclatures []*PromotionNomenclature
+
+	for offset := uint(0); ; offset += calendarNomenclaturesLimit {
+		page := &nomenclaturesResponse{}
+
+		query := url.Values{}
+		query.Set("promotionID", fmt.Sprintf("%d", promotionID))
+		query.Set("inAction", fmt.Sprintf("%t", inAction))
+		query.Set("limit", fmt.Sprintf("%d", calendarNomenclaturesLimit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.calendar, calendarPathNomenclatures, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, calendarRateLimit); err != nil {
+			return nil, err
+		}
+
+		nomenclatures = append(nomenclatures, page.Data.Nomenclatures...)
+
+		if uint(len(page.Data.Nomenclatures)) < calendarNomenclaturesLimit {
+			break
+		}
+	}
+
+	return nomenclatures, nil
+}
+
+// UploadPromotionNomenclatures добавляет карточки в акцию с применением цен сразу
+// и возвращает загрузки. Карточки загружаются по 1000 за запрос.
+// При ошибке возвращаются загрузки, которые успели выполниться
+func (c *Client) UploadPromotionNomenclatures(promotionID uint32, nmIDs []uint32) ([]*PromotionUpload, error) {
+	c.logger.Debug(fmt.Sprintf("Добавление %d карточек в акцию %d", len(nmIDs), promotionID))
+
+	var uploads []*PromotionUpload
+
+	for i := 0; i < len(nmIDs); i += calendarUploadLimit {
+		batch := nmIDs[i:min(i+calendarUploadLimit, len(nmIDs))]
+
+		body := &promotionUploadRequest{}
+		body.Data.PromotionID = promotionID
+		body.Data.UploadNow = true
+		body.Data.Nomenclatures = batch
+
+		result := &promotionUploadResponse{}
+
+		uri := fmt.Sprintf("%s/%s", c.baseURL.calendar, calendarPathUpload)
+
+		if err := c.requestJSON(http.MethodPost, uri, body, result, calendarRateLimit); err != nil {
+			return uploads, err
+		}
+
+		uploads = append(uploads, &PromotionUpload{UploadID: result.Data.UploadID, NmIDs: batch})
+	}
+
+	return uploads, nil
+}
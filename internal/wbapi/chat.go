@@ -0,0 +1,225 @@
+package wbapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	chatPathChats    string = "api/v1/seller/chats"
+	chatPathEvents   string = "api/v1/seller/events"
+	chatPathMessage  string = "api/v1/seller/message"
+	chatPathDownload string = "api/v1/seller/download"
+)
+
+// chatRateLimit ограничение количества отправленных запросов в минуту.
+// 10 запросов за 10 секунд к чату с покупателями
+var chatRateLimit *rateLimit = &rateLimit{interval: time.Second}
+
+// ChatGoodCard описывает товар, по которому покупатель начал чат
+type ChatGoodCard struct {
+	Date          string  `json:"date"`
+	NeedRefund    bool    `json:"needRefund"`
+	NmID          uint32  `json:"nmID"`
+	Price         float64 `json:"price"`
+	PriceCurrency string  `json:"priceCurrency"`
+	Rid           string  `json:"rid"`
+	Size          string  `json:"size"`
+}
+
+// Chat описывает чат с покупателем
+type Chat struct {
+	ChatID      string        `json:"chatID"`
+	ReplySign   string        `json:"replySign"`
+	ClientID    string        `json:"clientID"`
+	ClientName  string        `json:"clientName"`
+	GoodCard    *ChatGoodCard `json:"goodCard"`
+	LastMessage *struct {
+		Text         string `json:"text"`
+		AddTimestamp int64  `json:"addTimestamp"`
+	} `json:"lastMessage"`
+}
+
+// ChatFile описывает файл или изображение, приложенное к сообщению
+type ChatFile struct {
+	ContentType string `json:"contentType"`
+	Date        string `json:"date"`
+	DownloadID  string `json:"downloadID"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Size        int64  `json:"size"`
+}
+
+// ChatEvent описывает событие в чате
+type ChatEvent struct {
+	ChatID       string `json:"chatID"`
+	EventID      string `json:"eventID"`
+	EventType    string `json:"eventType"`
+	IsNewChat    bool   `json:"isNewChat"`
+	Source       string `json:"source"`
+	AddTimestamp int64  `json:"addTimestamp"`
+	AddTime      string `json:"addTime"`
+	ReplySign    string `json:"replySign"`
+	Sender       string `json:"sender"`
+	ClientID     string `json:"clientID"`
+	ClientName   string `json:"clientName"`
+	Message      *struct {
+		Text        string `json:"text"`
+		Attachments *struct {
+			GoodCard *ChatGoodCard `json:"goodCard"`
+			Files    []*ChatFile   `json:"files"`
+			Images   []*ChatFile   `json:"images"`
+		} `json:"attachments"`
+	} `json:"message"`
+}
+
+// ChatEvents описывает страницу событий чатов.
+// Next используется как курсор для получения следующей страницы
+type ChatEvents struct {
+	Next            int64        `json:"next"`
+	NewestEventTime string       `json:"newestEventTime"`
+	OldestEventTime string       `json:"oldestEventTime"`
+	TotalEvents     int          `json:"totalEvents"`
+	Events          []*ChatEvent `json:"events"`
+}
+
+// chatsResponse описывает ответ со списком чатов
+type chatsResponse struct {
+	Result []*Chat `json:"result"`
+}
+
+// chatEventsResponse описывает ответ со страницей событий
+type chatEventsResponse struct {
+	Result ChatEvents `json:"result"`
+}
+
+// GetChats возвращает список чатов продавца
+func (c *Client) GetChats() ([]*Chat, error) {
+	c.logger.Debug("Получение списка чатов с покупателями")
+
+	chats := &chatsResponse{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.chat, chatPathChats)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, chats, chatRateLimit); err != nil {
+		return nil, err
+	}
+
+	return chats.Result, nil
+}
+
+// GetChatEvents возвращает все события чатов, начиная с курсора next.
+// Если next равен 0, то события возвращаются с самого раннего доступного.
+// Так как получить за раз можно не все события, выполняются несколько запросов,
+// в ответе возвращается курсор для следующего вызова
+func (c *Client) GetChatEvents(next int64) ([]*ChatEvent, int64, error) {
+	c.logger.Debug(fmt.Sprintf("Получение событий чатов с курсора %d", next))
+
+	var events []*ChatEvent
+
+	for {
+		page := &chatEventsResponse{}
+
+		uri := fmt.Sprintf("%s/%s", c.baseURL.chat, chatPathEvents)
+		if next > 0 {
+			query := url.Values{}
+			query.Set("next", fmt.Sprintf("%d", next))
+			uri = fmt.Sprintf("%s?%s", uri, query.Encode())
+		}
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, chatRateLimit); err != nil {
+			return nil, next, err
+		}
+
+		events = append(events, page.Result.Events...)
+
+		if page.Result.TotalEvents == 0 || page.Result.Next <= next {
+			break
+		}
+		next = page.Result.Next
+	}
+
+	return events, next, nil
+}
+
+// SendChatMessage отправляет сообщение в чат с файлами по указанным путям.
+// replySign берется из чата или события чата
+func (c *Client) SendChatMessage(replySign string, text string, files []string) error {
+	c.logger.Debug("Отправка сообщения в чат с покупателем")
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	if err := writer.WriteField("replySign", replySign); err != nil {
+		return err
+	}
+	if text != "" {
+		if err := writer.WriteField("message", text); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range files {
+		if err := writeMultipartFile(writer, path); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.chat, chatPathMessage)
+
+	res, err := c.requestWithContentType(http.MethodPost, uri, body.Bytes(), writer.FormDataContentType(), chatRateLimit)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	return respCodeCheck(res)
+}
+
+// writeMultipartFile добавляет файл в поле file формы
+func writeMultipartFile(writer *multipart.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+
+	return err
+}
+
+// DownloadChatFile возвращает содержимое файла из чата по его downloadID
+func (c *Client) DownloadChatFile(downloadID string) ([]byte, error) {
+	c.logger.Debug(fmt.Sprintf("Загрузка файла %s из чата", downloadID))
+
+	uri := fmt.Sprintf("%s/%s/%s", c.baseURL.chat, chatPathDownload, url.PathEscape(downloadID))
+
+	res, err := c.getRequest(uri, chatRateLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err := respCodeCheck(res); err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(res.Body)
+}
@@ -15,9 +15,9 @@ const (
 	contentRequestLimit         uint   = 100
 )
 
-// contentRequestTicker канал контролирующй количество отправленных запросов в минуту.
+// contentRateLimit ограничение количества отправленных запросов в минуту.
 // 60 запросов в минуту в разделе контента (раз в секунду)
-var contentRequestTicker <-chan time.Time = time.NewTicker(time.Second).C
+var contentRateLimit *rateLimit = &rateLimit{interval: time.Second}
 
 // ContentCardCursor описывает блок size в карточке товара
 type ContentCardCursor struct {
@@ -178,7 +178,7 @@ func (c *Client) GetCards() (*ContentCards, error) {
 func (c *Client) getCards(url string, jsonBody []byte) (*ContentCards, error) {
 	contentCards := &ContentCards{}
 
-	res, err := c.postRequest(url, jsonBody, contentRequestTicker)
+	res, err := c.postRequest(url, jsonBody, contentRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -212,7 +212,7 @@ func (c *Client) MoveToTrash(nmIDs []uint32) error {
 		return err
 	}
 
-	res, err := c.postRequest(url, jsonBody, contentRequestTicker)
+	res, err := c.postRequest(url, jsonBody, contentRateLimit)
 	if err != nil {
 		return err
 	}
@@ -244,7 +244,7 @@ func (c *Client) RecoverCards(nmIDs []uint32) error {
 		return err
 	}
 
-	res, err := c.postRequest(url, jsonBody, contentRequestTicker)
+	res, err := c.postRequest(url, jsonBody, contentRateLimit)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,144 @@
+package wbapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	documentsPathCategories string = "api/v1/documents/categories"
+	documentsPathList       string = "api/v1/documents/list"
+	documentsPathDownload   string = "api/v1/documents/download"
+	documentsListLimit      uint   = 50
+)
+
+// documentsRateLimit ограничение количества отправленных запросов в минуту.
+// 1 запрос в 10 секунд к документам
+var documentsRateLimit *rateLimit = &rateLimit{interval: time.Second * 10}
+
+// DocumentCategory описывает категорию документов
+type DocumentCategory struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+}
+
+// Document описывает документ продавца.
+// Документ можно скачать в любом из форматов Extensions
+type Document struct {
+	ServiceName  string   `json:"serviceName"`
+	Name         string   `json:"name"`
+	Category     string   `json:"category"`
+	Extensions   []string `json:"extensions"`
+	CreationTime string   `json:"creationTime"`
+	Viewed       bool     `json:"viewed"`
+}
+
+// DocumentFile описывает скачанный документ
+type DocumentFile struct {
+	FileName  string
+	Extension string
+	Data      []byte
+}
+
+// documentCategoriesResponse описывает ответ со списком категорий
+type documentCategoriesResponse struct {
+	Data struct {
+		Categories []*DocumentCategory `json:"categories"`
+	} `json:"data"`
+}
+
+// documentsResponse описывает страницу списка документов
+type documentsResponse struct {
+	Data struct {
+		Documents []*Document `json:"documents"`
+	} `json:"data"`
+}
+
+// documentDownloadResponse описывает ответ с документом в base64
+type documentDownloadResponse struct {
+	Data struct {
+		FileName  string `json:"fileName"`
+		Extension string `json:"extension"`
+		Document  string `json:"document"`
+	} `json:"data"`
+}
+
+// GetDocumentCategories возвращает категории документов
+func (c *Client) GetDocumentCategories() ([]*DocumentCategory, error) {
+	c.logger.Debug("Получение категорий документов")
+
+	categories := &documentCategoriesResponse{}
+
+	uri := fmt.Sprintf("%s/%s?locale=ru", c.baseURL.documents, documentsPathCategories)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, categories, documentsRateLimit); err != nil {
+		return nil, err
+	}
+
+	return categories.Data.Categories, nil
+}
+
+// GetDocuments возвращает документы, созданные с beginTime по endTime в формате YYYY-MM-DD.
+// Если category пустая, то возвращаются документы всех категорий
+func (c *Client) GetDocuments(beginTime string, endTime string, category string) ([]*Document, error) {
+	c.logger.Debug(fmt.Sprintf("Получение документов с %s по %s", beginTime, endTime))
+
+	var documents []*Document
+
+	for offset := uint(0); ; offset += documentsListLimit {
+		page := &documentsResponse{}
+
+		query := url.Values{}
+		query.Set("locale", "ru")
+		query.Set("beginTime", beginTime)
+		query.Set("endTime", endTime)
+		query.Set("sort", "date")
+		query.Set("order", "desc")
+		query.Set("limit", fmt.Sprintf("%d", documentsListLimit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
+		if category != "" {
+			query.Set("category", category)
+		}
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.documents, documentsPathList, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, documentsRateLimit); err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, page.Data.Documents...)
+
+		if uint(len(page.Data.Documents)) < documentsListLimit {
+			break
+		}
+	}
+
+	return documents, nil
+}
+
+// DownloadDocument возвращает документ в указанном формате
+func (c *Client) DownloadDocument(serviceName string, extension string) (*DocumentFile, error) {
+	c.logger.Debug(fmt.Sprintf("Загрузка документа %s в формате %s", serviceName, extension))
+
+	document := &documentDownloadResponse{}
+
+	query := url.Values{}
+	query.Set("serviceName", serviceName)
+	query.Set("extension", extension)
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.documents, documentsPathDownload, query.Encode())
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, document, documentsRateLimit); err != nil {
+		return nil, err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(document.Data.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DocumentFile{FileName: document.Data.FileName, Extension: document.Data.Extension, Data: data}, nil
+}
@@ -0,0 +1,261 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	feedbacksPathFeedbacks                string = "api/v1/feedbacks"
+	feedbacksPathFeedbacksAnswer          string = "api/v1/feedbacks/answer"
+	feedbacksPathFeedbacksCountUnanswered string = "api/v1/feedbacks/count-unanswered"
+	feedbacksPathQuestions                string = "api/v1/questions"
+	feedbacksPathQuestionsCountUnanswered string = "api/v1/questions/count-unanswered"
+	feedbacksFeedbacksLimit               uint   = 5000
+	feedbacksQuestionsLimit               uint   = 10000
+	feedbacksQuestionStateAnswered        string = "wbRu"
+)
+
+// feedbacksRateLimit ограничение количества отправленных запросов в минуту.
+// 3 запроса в секунду в разделе отзывов и вопросов
+var feedbacksRateLimit *rateLimit = &rateLimit{interval: time.Millisecond * 400}
+
+// FeedbackProductDetails описывает товар, к которому относится отзыв или вопрос
+type FeedbackProductDetails struct {
+	ImtID           uint32 `json:"imtId"`
+	NmID            uint32 `json:"nmId"`
+	ProductName     string `json:"productName"`
+	SupplierArticle string `json:"supplierArticle"`
+	BrandName       string `json:"brandName"`
+	Size            string `json:"size"`
+}
+
+// FeedbackAnswer описывает ответ продавца на отзыв или вопрос
+type FeedbackAnswer struct {
+	Text     string `json:"text"`
+	State    string `json:"state,omitempty"`
+	Editable bool   `json:"editable"`
+}
+
+// Feedback описывает отзыв покупателя
+type Feedback struct {
+	ID               string                 `json:"id"`
+	UserName         string                 `json:"userName"`
+	Text             string                 `json:"text"`
+	Pros             string                 `json:"pros"`
+	Cons             string                 `json:"cons"`
+	ProductValuation uint8                  `json:"productValuation"`
+	CreatedDate      string                 `json:"createdDate"`
+	State            string                 `json:"state"`
+	Answer           *FeedbackAnswer        `json:"answer"`
+	ProductDetails   FeedbackProductDetails `json:"productDetails"`
+	SubjectName      string                 `json:"subjectName"`
+	WasViewed        bool                   `json:"wasViewed"`
+}
+
+// Question описывает вопрос покупателя
+type Question struct {
+	ID             string                 `json:"id"`
+	Text           string                 `json:"text"`
+	CreatedDate    string                 `json:"createdDate"`
+	State          string                 `json:"state"`
+	Answer         *FeedbackAnswer        `json:"answer"`
+	ProductDetails FeedbackProductDetails `json:"productDetails"`
+	WasViewed      bool                   `json:"wasViewed"`
+}
+
+// FeedbacksCountUnanswered описывает количество необработанных отзывов или вопросов.
+// Valuation содержит среднюю оценку товаров продавца и заполняется только для отзывов
+type FeedbacksCountUnanswered struct {
+	CountUnanswered      uint32 `json:"countUnanswered"`
+	CountUnansweredToday uint32 `json:"countUnansweredToday"`
+	Valuation            string `json:"valuation,omitempty"`
+}
+
+// feedbacksResponse описывает страницу списка отзывов
+type feedbacksResponse struct {
+	Data struct {
+		Feedbacks []*Feedback `json:"feedbacks"`
+	} `json:"data"`
+}
+
+// questionsResponse описывает страницу списка вопросов
+type questionsResponse struct {
+	Data struct {
+		Questions []*Question `json:"questions"`
+	} `json:"data"`
+}
+
+// feedbacksCountUnansweredResponse описывает ответ с количеством необработанных отзывов или вопросов
+type feedbacksCountUnansweredResponse struct {
+	Data FeedbacksCountUnanswered `json:"data"`
+}
+
+// feedbackAnswerRequest описывает тело запроса ответа на отзыв
+type feedbackAnswerRequest struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// questionAnswerRequest описывает тело запроса ответа на вопрос
+type questionAnswerRequest struct {
+	ID     string `json:"id"`
+	Answer struct {
+		Text string `json:"text"`
+	} `json:"answer"`
+	State string `json:"state"`
+}
+
+// viewedRequest описывает тело запроса для отметки отзыва или вопроса просмотренным
+type viewedRequest struct {
+	ID        string `json:"id"`
+	WasViewed bool   `json:"wasViewed"`
+}
+
+// GetFeedbacks возвращает отзывы, созданные начиная с dateFrom.
+// isAnswered определяет обработанные или необработанные отзывы нужно получить.
+// Так как получить за раз можно не все отзывы, выполняются несколько запросов
+func (c *Client) GetFeedbacks(isAnswered bool, dateFrom time.Time) ([]*Feedback, error) {
+	c.logger.Debug(fmt.Sprintf("Получение отзывов, обработанные: %t", isAnswered))
+
+	var feedbacks []*Feedback
+
+	for skip := uint(0); ; skip += feedbacksFeedbacksLimit {
+		page := &feedbacksResponse{}
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.feedbacks, feedbacksPathFeedbacks, feedbacksQuery(isAnswered, dateFrom, feedbacksFeedbacksLimit, skip))
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, feedbacksRateLimit); err != nil {
+			return nil, err
+		}
+
+		feedbacks = append(feedbacks, page.Data.Feedbacks...)
+
+		if uint(len(page.Data.Feedbacks)) < feedbacksFeedbacksLimit {
+			break
+		}
+	}
+
+	return feedbacks, nil
+}
+
+// GetQuestions возвращает вопросы, созданные начиная с dateFrom.
+// isAnswered определяет обработанные или необработанные вопросы нужно получить.
+// Так как получить за раз можно не все вопросы, выполняются несколько запросов
+func (c *Client) GetQuestions(isAnswered bool, dateFrom time.Time) ([]*Question, error) {
+	c.logger.Debug(fmt.Sprintf("Получение вопросов, обработанные: %t", isAnswered))
+
+	var questions []*Question
+
+	for skip := uint(0); ; skip += feedbacksQuestionsLimit {
+		page := &questionsResponse{}
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.feedbacks, feedbacksPathQuestions, feedbacksQuery(isAnswered, dateFrom, feedbacksQuestionsLimit, skip))
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, feedbacksRateLimit); err != nil {
+			return nil, err
+		}
+
+		questions = append(questions, page.Data.Questions...)
+
+		if uint(len(page.Data.Questions)) < feedbacksQuestionsLimit {
+			break
+		}
+	}
+
+	return questions, nil
+}
+
+// feedbacksQuery возвращает параметры запроса списка отзывов или вопросов
+func feedbacksQuery(isAnswered bool, dateFrom time.Time, take uint, skip uint) string {
+	query := url.Values{}
+	query.Set("isAnswered", fmt.Sprintf("%t", isAnswered))
+	query.Set("take", fmt.Sprintf("%d", take))
+	query.Set("skip", fmt.Sprintf("%d", skip))
+	query.Set("order", "dateDesc")
+	if !dateFrom.IsZero() {
+		query.Set("dateFrom", fmt.Sprintf("%d", dateFrom.Unix()))
+	}
+
+	return query.Encode()
+}
+
+// GetFeedbacksCountUnanswered возвращает количество необработанных отзывов и среднюю оценку товаров
+func (c *Client) GetFeedbacksCountUnanswered() (*FeedbacksCountUnanswered, error) {
+	c.logger.Debug("Получение количества необработанных отзывов")
+
+	count := &feedbacksCountUnansweredResponse{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathFeedbacksCountUnanswered)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, count, feedbacksRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &count.Data, nil
+}
+
+// GetQuestionsCountUnanswered возвращает количество необработанных вопросов
+func (c *Client) GetQuestionsCountUnanswered() (*FeedbacksCountUnanswered, error) {
+	c.logger.Debug("Получение количества необработанных вопросов")
+
+	count := &feedbacksCountUnansweredResponse{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathQuestionsCountUnanswered)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, count, feedbacksRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &count.Data, nil
+}
+
+// AnswerFeedback отвечает на отзыв
+func (c *Client) AnswerFeedback(id string, text string) error {
+	c.logger.Debug(fmt.Sprintf("Ответ на отзыв %s", id))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathFeedbacksAnswer)
+
+	return c.requestJSON(http.MethodPost, uri, &feedbackAnswerRequest{ID: id, Text: text}, nil, feedbacksRateLimit)
+}
+
+// EditFeedbackAnswer изменяет ответ на отзыв
+func (c *Client) EditFeedbackAnswer(id string, text string) error {
+	c.logger.Debug(fmt.Sprintf("Изменение ответа на отзыв %s", id))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathFeedbacksAnswer)
+
+	return c.requestJSON(http.MethodPatch, uri, &feedbackAnswerRequest{ID: id, Text: text}, nil, feedbacksRateLimit)
+}
+
+// MarkFeedbackViewed отмечает отзыв просмотренным
+func (c *Client) MarkFeedbackViewed(id string) error {
+	c.logger.Debug(fmt.Sprintf("Отметка отзыва %s просмотренным", id))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathFeedbacks)
+
+	return c.requestJSON(http.MethodPatch, uri, &viewedRequest{ID: id, WasViewed: true}, nil, feedbacksRateLimit)
+}
+
+// AnswerQuestion отвечает на вопрос или изменяет ответ
+func (c *Client) AnswerQuestion(id string, text string) error {
+	c.logger.Debug(fmt.Sprintf("Ответ на вопрос %s", id))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathQuestions)
+
+	body := &questionAnswerRequest{ID: id, State: feedbacksQuestionStateAnswered}
+	body.Answer.Text = text
+
+	return c.requestJSON(http.MethodPatch, uri, body, nil, feedbacksRateLimit)
+}
+
+// MarkQuestionViewed отмечает вопрос просмотренным
+func (c *Client) MarkQuestionViewed(id string) error {
+	c.logger.Debug(fmt.Sprintf("Отметка вопроса %s просмотренным", id))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.feedbacks, feedbacksPathQuestions)
+
+	return c.requestJSON(http.MethodPatch, uri, &viewedRequest{ID: id, WasViewed: true}, nil, feedbacksRateLimit)
+}
@@ -0,0 +1,37 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	financePathBalance string = "api/v1/account/balance"
+)
+
+// financeRateLimit ограничение количества отправленных запросов в минуту.
+// 1 запрос в минуту к балансу продавца
+var financeRateLimit *rateLimit = &rateLimit{interval: time.Minute}
+
+// Balance описывает баланс продавца
+type Balance struct {
+	Currency    string  `json:"currency"`
+	Current     float64 `json:"current"`
+	ForWithdraw float64 `json:"for_withdraw"`
+}
+
+// GetBalance возвращает баланс продавца
+func (c *Client) GetBalance() (*Balance, error) {
+	c.logger.Debug("Получение баланса продавца")
+
+	balance := &Balance{}
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.finance, financePathBalance)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, balance, financeRateLimit); err != nil {
+		return nil, err
+	}
+
+	return balance, nil
+}
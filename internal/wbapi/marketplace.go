@@ -3,6 +3,7 @@ package wbapi
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -10,12 +11,13 @@ const (
 	marketplacePathPing       string = "ping"
 	marketplacePathWarehouses string = "api/v3/warehouses"
 	marketplacePathStocks     string = "api/v3/stocks"
+	marketplacePathOffices    string = "api/v3/offices"
 	marketplaceSkusLimit      int    = 1000
 )
 
-// marketplaceRequestTicker канал контролирующй количество отправленных запросов в минуту.
+// marketplaceRateLimit ограничение количества отправленных запросов в минуту.
 // 120 запросов в минуту в разделе маркетплейса (2 раза в секунду).
-var marketplaceRequestTicker <-chan time.Time = time.NewTicker(time.Millisecond * 500).C
+var marketplaceRateLimit *rateLimit = &rateLimit{interval: time.Millisecond * 500}
 
 // Warehouse описывает склад продавца
 type Warehouse struct {
@@ -26,6 +28,30 @@ type Warehouse struct {
 	DeliveryType uint32 `json:"deliveryType"`
 }
 
+// Office описывает склад WB, к которому можно привязать склад продавца
+type Office struct {
+	ID           uint32  `json:"id"`
+	Name         string  `json:"name"`
+	Address      string  `json:"address"`
+	City         string  `json:"city"`
+	Longitude    float64 `json:"longitude"`
+	Latitude     float64 `json:"latitude"`
+	CargoType    uint32  `json:"cargoType"`
+	DeliveryType uint32  `json:"deliveryType"`
+	Selected     bool    `json:"selected"`
+}
+
+// warehouseRequest описывает тело запроса для создания и изменения склада продавца
+type warehouseRequest struct {
+	Name     string `json:"name"`
+	OfficeID uint32 `json:"officeId"`
+}
+
+// warehouseCreateResponse описывает ответ на создание склада продавца
+type warehouseCreateResponse struct {
+	ID uint32 `json:"id"`
+}
+
 // Stock описывает остаток на складе продавца
 type Stock struct {
 	Sku    string `json:"sku"`
@@ -50,7 +76,7 @@ func (c *Client) GetWarehouses() ([]*Warehouse, error) {
 
 	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathWarehouses)
 
-	res, err := c.getRequest(url, marketplaceRequestTicker)
+	res, err := c.getRequest(url, marketplaceRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +94,58 @@ func (c *Client) GetWarehouses() ([]*Warehouse, error) {
 	return warehouses, nil
 }
 
+// GetOffices получает список складов WB для привязки складов продавца
+func (c *Client) GetOffices() ([]*Office, error) {
+	c.logger.Debug("Получение списка складов WB")
+
+	var offices []*Office
+
+	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathOffices)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, &offices, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return offices, nil
+}
+
+// CreateWarehouse создает склад продавца и возвращает его ID
+func (c *Client) CreateWarehouse(name string, officeID uint32) (uint32, error) {
+	c.logger.Debug(fmt.Sprintf("Создание склада продавца %s", name))
+
+	warehouse := &warehouseCreateResponse{}
+
+	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathWarehouses)
+
+	body := &warehouseRequest{Name: name, OfficeID: officeID}
+
+	if err := c.requestJSON(http.MethodPost, url, body, warehouse, marketplaceRateLimit); err != nil {
+		return 0, err
+	}
+
+	return warehouse.ID, nil
+}
+
+// UpdateWarehouse изменяет имя склада продавца и склад WB, к которому он привязан
+func (c *Client) UpdateWarehouse(warehouseID uint32, name string, officeID uint32) error {
+	c.logger.Debug(fmt.Sprintf("Изменение склада продавца %d", warehouseID))
+
+	url := fmt.Sprintf("%s/%s/%d", c.baseURL.marketplace, marketplacePathWarehouses, warehouseID)
+
+	body := &warehouseRequest{Name: name, OfficeID: officeID}
+
+	return c.requestJSON(http.MethodPut, url, body, nil, marketplaceRateLimit)
+}
+
+// DeleteWarehouse удаляет склад продавца
+func (c *Client) DeleteWarehouse(warehouseID uint32) error {
+	c.logger.Debug(fmt.Sprintf("Удаление склада продавца %d", warehouseID))
+
+	url := fmt.Sprintf("%s/%s/%d", c.baseURL.marketplace, marketplacePathWarehouses, warehouseID)
+
+	return c.requestJSON(http.MethodDelete, url, nil, nil, marketplaceRateLimit)
+}
+
 // GetStocks получает остатки по слкаду продавца,
 // можно передать массив больше 1000, в этом случае запросы разделятся на части
 func (c *Client) GetStocks(warehouse Warehouse, skus []string) (*Stocks, error) {
@@ -118,7 +196,7 @@ func (c *Client) GetStocks(warehouse Warehouse, skus []string) (*Stocks, error)
 func (c *Client) getStocks(url string, jsonBody []byte) (*Stocks, error) {
 	var stocks *Stocks
 
-	res, err := c.postRequest(url, jsonBody, marketplaceRequestTicker)
+	res, err := c.postRequest(url, jsonBody, marketplaceRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,197 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// DeliveryFlow описывает схему работы со сборочными заданиями, которые продавец доставляет
+// или выдает покупателю сам
+type DeliveryFlow string
+
+const (
+	// DeliveryFlowDBS доставка силами продавца
+	DeliveryFlowDBS DeliveryFlow = "dbs"
+	// DeliveryFlowExpress самовывоз из магазина продавца (Click & Collect)
+	DeliveryFlowExpress DeliveryFlow = "click-collect"
+)
+
+const (
+	marketplacePathDeliveryOrders string = "api/v3/%s/orders"
+	marketplaceStatusesLimit      int    = 1000
+)
+
+// Статусы сборочного задания продавца (supplierStatus)
+const (
+	DeliveryStatusNew     string = "new"
+	DeliveryStatusConfirm string = "confirm"
+	DeliveryStatusDeliver string = "deliver"
+	DeliveryStatusPrepare string = "prepare"
+	DeliveryStatusReceive string = "receive"
+	DeliveryStatusReject  string = "reject"
+	DeliveryStatusCancel  string = "cancel"
+)
+
+// DeliveryAddress описывает адрес доставки сборочного задания DBS
+type DeliveryAddress struct {
+	FullAddress string  `json:"fullAddress"`
+	Longitude   float64 `json:"longitude"`
+	Latitude    float64 `json:"latitude"`
+}
+
+// DeliveryOrder описывает сборочное задание DBS или самовывоза
+type DeliveryOrder struct {
+	ID             uint64           `json:"id"`
+	Rid            string           `json:"rid"`
+	OrderUID       string           `json:"orderUid"`
+	GroupID        string           `json:"groupId"`
+	Article        string           `json:"article"`
+	NmID           uint32           `json:"nmId"`
+	ChrtID         uint64           `json:"chrtId"`
+	Skus           []string         `json:"skus"`
+	WarehouseID    uint32           `json:"warehouseId"`
+	Price          uint32           `json:"price"`
+	ConvertedPrice uint32           `json:"convertedPrice"`
+	CurrencyCode   uint32           `json:"currencyCode"`
+	CargoType      uint32           `json:"cargoType"`
+	DeliveryType   string           `json:"deliveryType"`
+	Address        *DeliveryAddress `json:"address,omitempty"`
+	Comment        string           `json:"comment"`
+	DDate          string           `json:"ddate"`
+	DTimeFrom      string           `json:"dTimeFrom"`
+	DTimeTo        string           `json:"dTimeTo"`
+	RequiredMeta   []string         `json:"requiredMeta,omitempty"`
+	CreatedAt      string           `json:"createdAt"`
+}
+
+// DeliveryOrderStatus описывает статусы сборочного задания у продавца и у WB
+type DeliveryOrderStatus struct {
+	ID             uint64 `json:"id"`
+	SupplierStatus string `json:"supplierStatus"`
+	WbStatus       string `json:"wbStatus"`
+}
+
+// DeliveryClient описывает информацию о покупателе сборочного задания
+type DeliveryClient struct {
+	OrderID          uint64 `json:"orderID"`
+	FirstName        string `json:"firstName"`
+	FullName         string `json:"fullName"`
+	Phone            string `json:"phone"`
+	PhoneCode        string `json:"phoneCode"`
+	ReplacementPhone string `json:"replacementPhone"`
+}
+
+// deliveryOrdersRequest описывает тело запроса со списком сборочных заданий
+type deliveryOrdersRequest struct {
+	Orders []uint64 `json:"orders"`
+}
+
+// deliveryCodeRequest описывает тело запроса с кодом подтверждения покупателя
+type deliveryCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// deliveryOrdersPath возвращает адрес раздела сборочных заданий схемы flow
+func (c *Client) deliveryOrdersPath(flow DeliveryFlow) string {
+	return fmt.Sprintf("%s/%s", c.baseURL.marketplace, fmt.Sprintf(marketplacePathDeliveryOrders, flow))
+}
+
+// GetNewDeliveryOrders получает список новых сборочных заданий схемы flow
+func (c *Client) GetNewDeliveryOrders(flow DeliveryFlow) ([]*DeliveryOrder, error) {
+	c.logger.Debug(fmt.Sprintf("Получение списка новых сборочных заданий %s", flow))
+
+	orders := &struct {
+		Orders []*DeliveryOrder `json:"orders"`
+	}{}
+
+	url := fmt.Sprintf("%s/new", c.deliveryOrdersPath(flow))
+
+	if err := c.requestJSON(http.MethodGet, url, nil, orders, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return orders.Orders, nil
+}
+
+// GetDeliveryOrderStatuses получает статусы сборочных заданий схемы flow,
+// можно передать массив больше 1000, в этом случае запросы разделятся на части
+func (c *Client) GetDeliveryOrderStatuses(flow DeliveryFlow, orderIDs []uint64) ([]*DeliveryOrderStatus, error) {
+	c.logger.Debug(fmt.Sprintf("Получение статусов %d сборочных заданий %s", len(orderIDs), flow))
+
+	var statuses []*DeliveryOrderStatus
+
+	url := fmt.Sprintf("%s/status", c.deliveryOrdersPath(flow))
+
+	for start := 0; start < len(orderIDs); start += marketplaceStatusesLimit {
+		stop := min(start+marketplaceStatusesLimit, len(orderIDs))
+
+		page := &struct {
+			Orders []*DeliveryOrderStatus `json:"orders"`
+		}{}
+		body := &deliveryOrdersRequest{Orders: orderIDs[start:stop]}
+
+		if err := c.requestJSON(http.MethodPost, url, body, page, marketplaceRateLimit); err != nil {
+			return nil, err
+		}
+
+		statuses = append(statuses, page.Orders...)
+	}
+
+	return statuses, nil
+}
+
+// GetDeliveryClients получает информацию о покупателях сборочных заданий схемы flow
+func (c *Client) GetDeliveryClients(flow DeliveryFlow, orderIDs []uint64) ([]*DeliveryClient, error) {
+	c.logger.Debug(fmt.Sprintf("Получение информации о покупателях %d сборочных заданий %s", len(orderIDs), flow))
+
+	clients := &struct {
+		Orders []*DeliveryClient `json:"orders"`
+	}{}
+
+	url := fmt.Sprintf("%s/client", c.deliveryOrdersPath(flow))
+
+	if err := c.requestJSON(http.MethodPost, url, &deliveryOrdersRequest{Orders: orderIDs}, clients, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return clients.Orders, nil
+}
+
+// ConfirmDeliveryOrder переводит сборочное задание в статус confirm (на сборке)
+func (c *Client) ConfirmDeliveryOrder(flow DeliveryFlow, orderID uint64) error {
+	return c.deliveryOrderAction(flow, orderID, DeliveryStatusConfirm, nil)
+}
+
+// DeliverDeliveryOrder переводит сборочное задание DBS в статус deliver (в доставке)
+func (c *Client) DeliverDeliveryOrder(orderID uint64) error {
+	return c.deliveryOrderAction(DeliveryFlowDBS, orderID, DeliveryStatusDeliver, nil)
+}
+
+// PrepareDeliveryOrder переводит сборочное задание самовывоза в статус prepare (готово к выдаче)
+func (c *Client) PrepareDeliveryOrder(orderID uint64) error {
+	return c.deliveryOrderAction(DeliveryFlowExpress, orderID, DeliveryStatusPrepare, nil)
+}
+
+// ReceiveDeliveryOrder сообщает, что покупатель получил товар. code код подтверждения от покупателя
+func (c *Client) ReceiveDeliveryOrder(flow DeliveryFlow, orderID uint64, code string) error {
+	return c.deliveryOrderAction(flow, orderID, DeliveryStatusReceive, &deliveryCodeRequest{Code: code})
+}
+
+// RejectDeliveryOrder сообщает, что покупатель отказался от товара. code код подтверждения от покупателя
+func (c *Client) RejectDeliveryOrder(flow DeliveryFlow, orderID uint64, code string) error {
+	return c.deliveryOrderAction(flow, orderID, DeliveryStatusReject, &deliveryCodeRequest{Code: code})
+}
+
+// CancelDeliveryOrder отменяет сборочное задание схемы flow
+func (c *Client) CancelDeliveryOrder(flow DeliveryFlow, orderID uint64) error {
+	return c.deliveryOrderAction(flow, orderID, DeliveryStatusCancel, nil)
+}
+
+// deliveryOrderAction переводит сборочное задание в следующий статус
+func (c *Client) deliveryOrderAction(flow DeliveryFlow, orderID uint64, action string, body any) error {
+	c.logger.Debug(fmt.Sprintf("Перевод сборочного задания %s %d в статус %s", flow, orderID, action))
+
+	url := fmt.Sprintf("%s/%d/%s", c.deliveryOrdersPath(flow), orderID, action)
+
+	return c.requestJSON(http.MethodPatch, url, body, nil, marketplaceRateLimit)
+}
@@ -0,0 +1,254 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	marketplacePathOrdersNew      string = "api/v3/orders/new"
+	marketplacePathOrdersStickers string = "api/v3/orders/stickers"
+	marketplacePathOrders         string = "api/v3/orders"
+	marketplaceStickersLimit      int    = 100
+)
+
+// Order описывает сборочное задание продавца
+type Order struct {
+	ID             uint64   `json:"id"`
+	Rid            string   `json:"rid"`
+	OrderUID       string   `json:"orderUid"`
+	SupplyID       string   `json:"supplyId,omitempty"`
+	Article        string   `json:"article"`
+	NmID           uint32   `json:"nmId"`
+	ChrtID         uint64   `json:"chrtId"`
+	Skus           []string `json:"skus"`
+	WarehouseID    uint32   `json:"warehouseId"`
+	Offices        []string `json:"offices"`
+	Price          uint32   `json:"price"`
+	ConvertedPrice uint32   `json:"convertedPrice"`
+	CurrencyCode   uint32   `json:"currencyCode"`
+	CargoType      uint32   `json:"cargoType"`
+	DeliveryType   string   `json:"deliveryType"`
+	RequiredMeta   []string `json:"requiredMeta,omitempty"`
+	CreatedAt      string   `json:"createdAt"`
+}
+
+// Orders описывает список сборочных заданий
+type Orders struct {
+	Orders []*Order `json:"orders"`
+}
+
+// GetNewOrders получает список новых сборочных заданий
+func (c *Client) GetNewOrders() ([]*Order, error) {
+	c.logger.Debug("Получение списка новых сборочных заданий")
+
+	orders := &Orders{}
+
+	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathOrdersNew)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, orders, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return orders.Orders, nil
+}
+
+// StickerSize описывает размер стикера в миллиметрах
+type StickerSize struct {
+	Width  uint32
+	Height uint32
+}
+
+var (
+	StickerSize58x40 StickerSize = StickerSize{Width: 58, Height: 40}
+	StickerSize40x30 StickerSize = StickerSize{Width: 40, Height: 30}
+)
+
+// String возвращает размер стикера в виде 58x40
+func (s StickerSize) String() string {
+	return fmt.Sprintf("%dx%d", s.Width, s.Height)
+}
+
+// ParseStickerSize разбирает размер стикера из строки вида 58x40
+func ParseStickerSize(s string) (StickerSize, error) {
+	for _, size := range []StickerSize{StickerSize58x40, StickerSize40x30} {
+		if size.String() == s {
+			return size, nil
+		}
+	}
+
+	return StickerSize{}, fmt.Errorf("неподдерживаемый размер стикера %s. Доступные размеры: 58x40, 40x30", s)
+}
+
+// OrderSticker описывает стикер сборочного задания
+// File содержит файл в кодировке base64
+type OrderSticker struct {
+	OrderID uint64 `json:"orderId"`
+	PartA   uint64 `json:"partA"`
+	PartB   uint64 `json:"partB"`
+	Barcode string `json:"barcode"`
+	File    string `json:"file"`
+}
+
+// orderStickersRequest описывает тело запроса для получения стикеров
+type orderStickersRequest struct {
+	Orders []uint64 `json:"orders"`
+}
+
+// orderStickersResponse описывает ответ на запрос стикеров
+type orderStickersResponse struct {
+	Stickers []*OrderSticker `json:"stickers"`
+}
+
+// GetOrderStickers получает стикеры сборочных заданий в указанном формате и размере,
+// можно передать массив больше 100, в этом случае запросы разделятся на части
+func (c *Client) GetOrderStickers(orderIDs []uint64, stickerType StickerType, size StickerSize) ([]*OrderSticker, error) {
+	c.logger.Debug(fmt.Sprintf("Получение стикеров для %d сборочных заданий", len(orderIDs)))
+
+	var stickers []*OrderSticker
+
+	url := fmt.Sprintf(
+		"%s/%s?type=%s&width=%d&height=%d",
+		c.baseURL.marketplace, marketplacePathOrdersStickers, stickerType, size.Width, size.Height,
+	)
+
+	for start := 0; start < len(orderIDs); start += marketplaceStickersLimit {
+		stop := start + marketplaceStickersLimit
+		if stop > len(orderIDs) {
+			stop = len(orderIDs)
+		}
+
+		page := &orderStickersResponse{}
+		body := &orderStickersRequest{Orders: orderIDs[start:stop]}
+
+		if err := c.requestJSON(http.MethodPost, url, body, page, marketplaceRateLimit); err != nil {
+			return nil, err
+		}
+
+		stickers = append(stickers, page.Stickers...)
+	}
+
+	return stickers, nil
+}
+
+// Ключи метаданных сборочного задания
+const (
+	OrderMetaSGTIN string = "sgtin"
+	OrderMetaUIN   string = "uin"
+	OrderMetaIMEI  string = "imei"
+	OrderMetaGTIN  string = "gtin"
+)
+
+// OrderMetaValue описывает значение метаданных сборочного задания
+type OrderMetaValue struct {
+	Value any `json:"value"`
+}
+
+// OrderMeta описывает метаданные сборочного задания
+type OrderMeta struct {
+	SGTIN *OrderMetaValue `json:"sgtin,omitempty"`
+	UIN   *OrderMetaValue `json:"uin,omitempty"`
+	IMEI  *OrderMetaValue `json:"imei,omitempty"`
+	GTIN  *OrderMetaValue `json:"gtin,omitempty"`
+}
+
+// Get возвращает значение метаданных по ключу
+func (m *OrderMeta) Get(key string) *OrderMetaValue {
+	switch key {
+	case OrderMetaSGTIN:
+		return m.SGTIN
+	case OrderMetaUIN:
+		return m.UIN
+	case OrderMetaIMEI:
+		return m.IMEI
+	case OrderMetaGTIN:
+		return m.GTIN
+	}
+
+	return nil
+}
+
+// Has проверяет заполнено ли значение метаданных по ключу
+func (m *OrderMeta) Has(key string) bool {
+	v := m.Get(key)
+
+	if v == nil || v.Value == nil {
+		return false
+	}
+
+	switch value := v.Value.(type) {
+	case string:
+		return value != ""
+	case []any:
+		return len(value) > 0
+	}
+
+	return true
+}
+
+// orderMetaResponse описывает ответ на запрос метаданных
+type orderMetaResponse struct {
+	Meta OrderMeta `json:"meta"`
+}
+
+// CancelOrder отменяет сборочное задание
+func (c *Client) CancelOrder(orderID uint64) error {
+	c.logger.Debug(fmt.Sprintf("Отмена сборочного задания %d", orderID))
+
+	url := fmt.Sprintf("%s/%s/%d/cancel", c.baseURL.marketplace, marketplacePathOrders, orderID)
+
+	return c.requestJSON(http.MethodPatch, url, nil, nil, marketplaceRateLimit)
+}
+
+// GetOrderMeta получает метаданные сборочного задания
+func (c *Client) GetOrderMeta(orderID uint64) (*OrderMeta, error) {
+	c.logger.Debug(fmt.Sprintf("Получение метаданных сборочного задания %d", orderID))
+
+	meta := &orderMetaResponse{}
+
+	url := fmt.Sprintf("%s/%s/%d/meta", c.baseURL.marketplace, marketplacePathOrders, orderID)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, meta, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return &meta.Meta, nil
+}
+
+// DeleteOrderMeta удаляет метаданные сборочного задания по ключу
+func (c *Client) DeleteOrderMeta(orderID uint64, key string) error {
+	c.logger.Debug(fmt.Sprintf("Удаление метаданных %s сборочного задания %d", key, orderID))
+
+	url := fmt.Sprintf("%s/%s/%d/meta?key=%s", c.baseURL.marketplace, marketplacePathOrders, orderID, key)
+
+	return c.requestJSON(http.MethodDelete, url, nil, nil, marketplaceRateLimit)
+}
+
+// SetOrderSGTIN закрепляет коды маркировки Честного знака (КИЗ) за сборочным заданием
+func (c *Client) SetOrderSGTIN(orderID uint64, sgtins []string) error {
+	return c.setOrderMeta(orderID, OrderMetaSGTIN, map[string][]string{"sgtins": sgtins})
+}
+
+// SetOrderUIN закрепляет УИН за сборочным заданием
+func (c *Client) SetOrderUIN(orderID uint64, uin string) error {
+	return c.setOrderMeta(orderID, OrderMetaUIN, map[string]string{"uin": uin})
+}
+
+// SetOrderIMEI закрепляет IMEI за сборочным заданием
+func (c *Client) SetOrderIMEI(orderID uint64, imei string) error {
+	return c.setOrderMeta(orderID, OrderMetaIMEI, map[string]string{"imei": imei})
+}
+
+// SetOrderGTIN закрепляет GTIN за сборочным заданием
+func (c *Client) SetOrderGTIN(orderID uint64, gtin string) error {
+	return c.setOrderMeta(orderID, OrderMetaGTIN, map[string]string{"gtin": gtin})
+}
+
+// setOrderMeta закрепляет метаданные с указанным ключом за сборочным заданием
+func (c *Client) setOrderMeta(orderID uint64, key string, body any) error {
+	c.logger.Debug(fmt.Sprintf("Закрепление метаданных %s за сборочным заданием %d", key, orderID))
+
+	url := fmt.Sprintf("%s/%s/%d/meta/%s", c.baseURL.marketplace, marketplacePathOrders, orderID, key)
+
+	return c.requestJSON(http.MethodPut, url, body, nil, marketplaceRateLimit)
+}
@@ -0,0 +1,174 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const (
+	marketplacePathSupplies  string = "api/v3/supplies"
+	marketplaceSuppliesLimit uint   = 1000
+)
+
+// StickerType описывает формат стикера или QR-кода поставки
+type StickerType string
+
+const (
+	StickerTypeSVG  StickerType = "svg"
+	StickerTypeZPLV StickerType = "zplv"
+	StickerTypeZPLH StickerType = "zplh"
+	StickerTypePNG  StickerType = "png"
+)
+
+// Supply описывает поставку сборочных заданий продавца
+type Supply struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Done      bool   `json:"done"`
+	CargoType uint32 `json:"cargoType"`
+	CreatedAt string `json:"createdAt"`
+	ClosedAt  string `json:"closedAt,omitempty"`
+	ScanDt    string `json:"scanDt,omitempty"`
+}
+
+// Supplies описывает страницу списка поставок
+type Supplies struct {
+	Next     uint64    `json:"next"`
+	Supplies []*Supply `json:"supplies"`
+}
+
+// SupplyBarcode описывает QR-код поставки
+// File содержит файл в кодировке base64
+type SupplyBarcode struct {
+	Barcode string `json:"barcode"`
+	File    string `json:"file"`
+}
+
+// supplyCreateRequest описывает тело запроса для создания поставки
+type supplyCreateRequest struct {
+	Name string `json:"name"`
+}
+
+// supplyCreateResponse описывает ответ на создание поставки
+type supplyCreateResponse struct {
+	ID string `json:"id"`
+}
+
+// CreateSupply создает новую поставку и возвращает ее ID
+func (c *Client) CreateSupply(name string) (string, error) {
+	c.logger.Debug(fmt.Sprintf("Создание поставки %s", name))
+
+	supply := &supplyCreateResponse{}
+
+	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathSupplies)
+
+	body := &supplyCreateRequest{Name: name}
+
+	if err := c.requestJSON(http.MethodPost, url, body, supply, marketplaceRateLimit); err != nil {
+		return "", err
+	}
+
+	return supply.ID, nil
+}
+
+// GetSupplies получает список всех поставок продавца
+// Так как получить за раз можно не все поставки, выполняются несколько запросов
+func (c *Client) GetSupplies() ([]*Supply, error) {
+	c.logger.Debug("Получение списка поставок")
+
+	var supplies []*Supply
+	var next uint64
+
+	for {
+		page := &Supplies{}
+
+		url := fmt.Sprintf("%s/%s?limit=%d&next=%d", c.baseURL.marketplace, marketplacePathSupplies, marketplaceSuppliesLimit, next)
+
+		if err := c.requestJSON(http.MethodGet, url, nil, page, marketplaceRateLimit); err != nil {
+			return nil, err
+		}
+
+		supplies = append(supplies, page.Supplies...)
+		next = page.Next
+
+		if uint(len(page.Supplies)) < marketplaceSuppliesLimit {
+			break
+		}
+	}
+
+	return supplies, nil
+}
+
+// GetSupply получает информацию о поставке
+func (c *Client) GetSupply(supplyID string) (*Supply, error) {
+	c.logger.Debug(fmt.Sprintf("Получение информации о поставке %s", supplyID))
+
+	supply := &Supply{}
+
+	url := fmt.Sprintf("%s/%s/%s", c.baseURL.marketplace, marketplacePathSupplies, supplyID)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, supply, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return supply, nil
+}
+
+// GetSupplyOrders получает список сборочных заданий поставки
+func (c *Client) GetSupplyOrders(supplyID string) ([]*Order, error) {
+	c.logger.Debug(fmt.Sprintf("Получение сборочных заданий поставки %s", supplyID))
+
+	orders := &Orders{}
+
+	url := fmt.Sprintf("%s/%s/%s/orders", c.baseURL.marketplace, marketplacePathSupplies, supplyID)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, orders, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return orders.Orders, nil
+}
+
+// AddOrderToSupply добавляет сборочное задание в поставку
+func (c *Client) AddOrderToSupply(supplyID string, orderID uint64) error {
+	c.logger.Debug(fmt.Sprintf("Добавление сборочного задания %d в поставку %s", orderID, supplyID))
+
+	url := fmt.Sprintf("%s/%s/%s/orders/%d", c.baseURL.marketplace, marketplacePathSupplies, supplyID, orderID)
+
+	return c.requestJSON(http.MethodPatch, url, nil, nil, marketplaceRateLimit)
+}
+
+// DeliverSupply закрывает поставку и передает ее в доставку
+func (c *Client) DeliverSupply(supplyID string) error {
+	c.logger.Debug(fmt.Sprintf("Передача поставки %s в доставку", supplyID))
+
+	url := fmt.Sprintf("%s/%s/%s/deliver", c.baseURL.marketplace, marketplacePathSupplies, supplyID)
+
+	return c.requestJSON(http.MethodPatch, url, nil, nil, marketplaceRateLimit)
+}
+
+// GetSupplyBarcode получает QR-код поставки в указанном формате
+// Получить QR-код можно только для поставки переданной в доставку
+func (c *Client) GetSupplyBarcode(supplyID string, stickerType StickerType) (*SupplyBarcode, error) {
+	c.logger.Debug(fmt.Sprintf("Получение QR-кода поставки %s", supplyID))
+
+	barcode := &SupplyBarcode{}
+
+	url := fmt.Sprintf("%s/%s/%s/barcode?type=%s", c.baseURL.marketplace, marketplacePathSupplies, supplyID, stickerType)
+
+	if err := c.requestJSON(http.MethodGet, url, nil, barcode, marketplaceRateLimit); err != nil {
+		return nil, err
+	}
+
+	return barcode, nil
+}
+
+// CancelSupply удаляет поставку
+// Удалить можно только активную поставку без сборочных заданий
+func (c *Client) CancelSupply(supplyID string) error {
+	c.logger.Debug(fmt.Sprintf("Удаление поставки %s", supplyID))
+
+	url := fmt.Sprintf("%s/%s/%s", c.baseURL.marketplace, marketplacePathSupplies, supplyID)
+
+	return c.requestJSON(http.MethodDelete, url, nil, nil, marketplaceRateLimit)
+}
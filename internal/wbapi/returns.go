@@ -0,0 +1,93 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	returnsPathClaims  string = "api/v1/claims"
+	returnsPathClaim   string = "api/v1/claim"
+	returnsClaimsLimit uint   = 200
+)
+
+// returnsRateLimit ограничение количества отправленных запросов в минуту.
+// 20 запросов в минуту к api возвратов
+var returnsRateLimit *rateLimit = &rateLimit{interval: time.Second * 3}
+
+// Claim описывает заявку покупателя на возврат
+type Claim struct {
+	ID           string   `json:"id"`
+	ClaimType    uint8    `json:"claim_type"`
+	Status       uint8    `json:"status"`
+	StatusEx     uint8    `json:"status_ex"`
+	NmID         uint32   `json:"nm_id"`
+	UserComment  string   `json:"user_comment"`
+	WbComment    string   `json:"wb_comment"`
+	Dt           string   `json:"dt"`
+	ImtName      string   `json:"imt_name"`
+	OrderDt      string   `json:"order_dt"`
+	DtUpdate     string   `json:"dt_update"`
+	Photos       []string `json:"photos"`
+	VideoPaths   []string `json:"video_paths"`
+	Actions      []string `json:"actions"`
+	Price        float64  `json:"price"`
+	CurrencyCode string   `json:"currency_code"`
+	Srid         string   `json:"srid"`
+}
+
+// claimsResponse описывает страницу списка заявок
+type claimsResponse struct {
+	Claims []*Claim `json:"claims"`
+	Total  uint     `json:"total"`
+}
+
+// claimAnswerRequest описывает тело запроса ответа на заявку
+type claimAnswerRequest struct {
+	ID      string `json:"id"`
+	Action  string `json:"action"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// GetClaims возвращает заявки покупателей на возврат за последние 14 дней.
+// isArchive определяет нужно получить рассмотренные или нерассмотренные заявки
+func (c *Client) GetClaims(isArchive bool) ([]*Claim, error) {
+	c.logger.Debug(fmt.Sprintf("Получение заявок на возврат, архивные: %t", isArchive))
+
+	var claims []*Claim
+
+	for offset := uint(0); ; offset += returnsClaimsLimit {
+		page := &claimsResponse{}
+
+		query := url.Values{}
+		query.Set("is_archive", fmt.Sprintf("%t", isArchive))
+		query.Set("limit", fmt.Sprintf("%d", returnsClaimsLimit))
+		query.Set("offset", fmt.Sprintf("%d", offset))
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.returns, returnsPathClaims, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, page, returnsRateLimit); err != nil {
+			return nil, err
+		}
+
+		claims = append(claims, page.Claims...)
+
+		if uint(len(page.Claims)) < returnsClaimsLimit {
+			break
+		}
+	}
+
+	return claims, nil
+}
+
+// AnswerClaim отвечает на заявку покупателя. Действие должно быть одним из доступных в Claim.Actions,
+// комментарий обязателен для действия rejectcustom
+func (c *Client) AnswerClaim(id string, action string, comment string) error {
+	c.logger.Debug(fmt.Sprintf("Ответ на заявку %s действием %s", id, action))
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.returns, returnsPathClaim)
+
+	return c.requestJSON(http.MethodPatch, uri, &claimAnswerRequest{ID: id, Action: action, Comment: comment}, nil, returnsRateLimit)
+}
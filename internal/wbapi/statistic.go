@@ -3,17 +3,27 @@ package wbapi
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"time"
 )
 
 const (
 	statisticsPathPing           string = "ping"
 	statisticsPathSupplierStocks string = "api/v1/supplier/stocks"
+	statisticsPathSupplierOrders string = "api/v1/supplier/orders"
+	statisticsPathSupplierSales  string = "api/v1/supplier/sales"
+	statisticsPathSupplierIncome string = "api/v1/supplier/incomes"
+	statisticsPathReportDetail   string = "api/v5/supplier/reportDetailByPeriod"
+	statisticsReportDetailLimit  uint   = 100000
+	statisticsPageLimit          int    = 80000
 )
 
-// statisticsRequestTicker канал содержащий количество отправленных запросов
-// 1 запрос в минуту в разделе статистика.
-var statisticsRequestTicker <-chan time.Time = time.NewTicker(time.Millisecond * 500).C
+// statisticsRateLimit ограничение запросов к ping и остаткам в разделе статистика
+var statisticsRateLimit *rateLimit = &rateLimit{interval: time.Millisecond * 500}
+
+// statisticsReportsRateLimit 1 запрос в минуту к заказам, продажам, поставкам и отчету о реализации
+var statisticsReportsRateLimit *rateLimit = &rateLimit{interval: time.Minute}
 
 // StatisticsSupplierStock описывает остатки на складе
 type StatisticsSupplierStock struct {
@@ -45,7 +55,7 @@ func (c *Client) GetStatisticsSupplierStock(dateFrom string) ([]*StatisticsSuppl
 
 	url := fmt.Sprintf("%s/%s?dateFrom=%s", c.baseURL.statistics, statisticsPathSupplierStocks, dateFrom)
 
-	res, err := c.getRequest(url, statisticsRequestTicker)
+	res, err := c.getRequest(url, statisticsRateLimit)
 	if err != nil {
 		return nil, err
 	}
@@ -62,3 +72,245 @@ func (c *Client) GetStatisticsSupplierStock(dateFrom string) ([]*StatisticsSuppl
 
 	return statisticsSupplierStocks, nil
 }
+
+// StatisticsOrder описывает заказ из отчета о заказах
+type StatisticsOrder struct {
+	Date            string  `json:"date"`
+	LastChangeDate  string  `json:"lastChangeDate"`
+	WarehouseName   string  `json:"warehouseName"`
+	WarehouseType   string  `json:"warehouseType"`
+	CountryName     string  `json:"countryName"`
+	OblastOkrugName string  `json:"oblastOkrugName"`
+	RegionName      string  `json:"regionName"`
+	SupplierArticle string  `json:"supplierArticle"`
+	NmID            uint32  `json:"nmId"`
+	Barcode         string  `json:"barcode"`
+	Category        string  `json:"category"`
+	Subject         string  `json:"subject"`
+	Brand           string  `json:"brand"`
+	TechSize        string  `json:"techSize"`
+	IncomeID        uint32  `json:"incomeID"`
+	IsSupply        bool    `json:"isSupply"`
+	IsRealization   bool    `json:"isRealization"`
+	TotalPrice      float64 `json:"totalPrice"`
+	DiscountPercent int32   `json:"discountPercent"`
+	Spp             float64 `json:"spp"`
+	FinishedPrice   float64 `json:"finishedPrice"`
+	PriceWithDisc   float64 `json:"priceWithDisc"`
+	IsCancel        bool    `json:"isCancel"`
+	CancelDate      string  `json:"cancelDate"`
+	OrderType       string  `json:"orderType"`
+	Sticker         string  `json:"sticker"`
+	GNumber         string  `json:"gNumber"`
+	Srid            string  `json:"srid"`
+}
+
+// GetStatisticsOrders возвращает список заказов.
+// При flag равном 0 возвращаются заказы, измененные начиная с dateFrom. Так как за раз можно
+// получить не все заказы, выполняются несколько запросов, следующий начинается с lastChangeDate
+// последней строки. При flag равном 1 возвращаются все заказы за дату dateFrom
+func (c *Client) GetStatisticsOrders(dateFrom string, flag int) ([]*StatisticsOrder, error) {
+	c.logger.Debug("Получение данных о заказах")
+
+	return getStatisticsPages(c, statisticsPathSupplierOrders, dateFrom, flag,
+		func(o *StatisticsOrder) string { return o.LastChangeDate })
+}
+
+// StatisticsSale описывает продажу или возврат из отчета о продажах.
+// SaleID возвратов начинается с буквы R
+type StatisticsSale struct {
+	Date              string  `json:"date"`
+	LastChangeDate    string  `json:"lastChangeDate"`
+	WarehouseName     string  `json:"warehouseName"`
+	WarehouseType     string  `json:"warehouseType"`
+	CountryName       string  `json:"countryName"`
+	OblastOkrugName   string  `json:"oblastOkrugName"`
+	RegionName        string  `json:"regionName"`
+	SupplierArticle   string  `json:"supplierArticle"`
+	NmID              uint32  `json:"nmId"`
+	Barcode           string  `json:"barcode"`
+	Category          string  `json:"category"`
+	Subject           string  `json:"subject"`
+	Brand             string  `json:"brand"`
+	TechSize          string  `json:"techSize"`
+	IncomeID          uint32  `json:"incomeID"`
+	IsSupply          bool    `json:"isSupply"`
+	IsRealization     bool    `json:"isRealization"`
+	TotalPrice        float64 `json:"totalPrice"`
+	DiscountPercent   int32   `json:"discountPercent"`
+	Spp               float64 `json:"spp"`
+	PaymentSaleAmount float64 `json:"paymentSaleAmount"`
+	ForPay            float64 `json:"forPay"`
+	FinishedPrice     float64 `json:"finishedPrice"`
+	PriceWithDisc     float64 `json:"priceWithDisc"`
+	SaleID            string  `json:"saleID"`
+	OrderType         string  `json:"orderType"`
+	Sticker           string  `json:"sticker"`
+	GNumber           string  `json:"gNumber"`
+	Srid              string  `json:"srid"`
+}
+
+// GetStatisticsSales возвращает список продаж и возвратов.
+// Параметры и порядок получения данных совпадают с GetStatisticsOrders
+func (c *Client) GetStatisticsSales(dateFrom string, flag int) ([]*StatisticsSale, error) {
+	c.logger.Debug("Получение данных о продажах и возвратах")
+
+	return getStatisticsPages(c, statisticsPathSupplierSales, dateFrom, flag,
+		func(s *StatisticsSale) string { return s.LastChangeDate })
+}
+
+// StatisticsIncome описывает строку поставки на склад WB.
+// Поставка состоит из нескольких строк, по одной на баркод
+type StatisticsIncome struct {
+	IncomeID        uint32  `json:"incomeId"`
+	Number          string  `json:"number"`
+	Date            string  `json:"date"`
+	LastChangeDate  string  `json:"lastChangeDate"`
+	SupplierArticle string  `json:"supplierArticle"`
+	TechSize        string  `json:"techSize"`
+	Barcode         string  `json:"barcode"`
+	Quantity        uint32  `json:"quantity"`
+	TotalPrice      float64 `json:"totalPrice"`
+	DateClose       string  `json:"dateClose"`
+	WarehouseName   string  `json:"warehouseName"`
+	NmID            uint32  `json:"nmId"`
+	Status          string  `json:"status"`
+}
+
+// GetStatisticsIncomes возвращает список поставок на склады WB, измененных начиная с dateFrom
+func (c *Client) GetStatisticsIncomes(dateFrom string) ([]*StatisticsIncome, error) {
+	c.logger.Debug("Получение данных о поставках")
+
+	return getStatisticsPages(c, statisticsPathSupplierIncome, dateFrom, -1,
+		func(i *StatisticsIncome) string { return i.LastChangeDate })
+}
+
+// StatisticsReportDetail описывает строку детализации отчета о реализации
+type StatisticsReportDetail struct {
+	RealizationReportID      uint64  `json:"realizationreport_id"`
+	DateFrom                 string  `json:"date_from"`
+	DateTo                   string  `json:"date_to"`
+	CreateDt                 string  `json:"create_dt"`
+	CurrencyName             string  `json:"currency_name"`
+	RrdID                    uint64  `json:"rrd_id"`
+	GiID                     uint64  `json:"gi_id"`
+	SubjectName              string  `json:"subject_name"`
+	NmID                     uint32  `json:"nm_id"`
+	BrandName                string  `json:"brand_name"`
+	SaName                   string  `json:"sa_name"`
+	TsName                   string  `json:"ts_name"`
+	Barcode                  string  `json:"barcode"`
+	DocTypeName              string  `json:"doc_type_name"`
+	Quantity                 int32   `json:"quantity"`
+	RetailPrice              float64 `json:"retail_price"`
+	RetailAmount             float64 `json:"retail_amount"`
+	SalePercent              float64 `json:"sale_percent"`
+	CommissionPercent        float64 `json:"commission_percent"`
+	OfficeName               string  `json:"office_name"`
+	SupplierOperName         string  `json:"supplier_oper_name"`
+	OrderDt                  string  `json:"order_dt"`
+	SaleDt                   string  `json:"sale_dt"`
+	RrDt                     string  `json:"rr_dt"`
+	ShkID                    uint64  `json:"shk_id"`
+	RetailPriceWithdiscRub   float64 `json:"retail_price_withdisc_rub"`
+	DeliveryAmount           int32   `json:"delivery_amount"`
+	ReturnAmount             int32   `json:"return_amount"`
+	DeliveryRub              float64 `json:"delivery_rub"`
+	GiBoxTypeName            string  `json:"gi_box_type_name"`
+	ProductDiscountForReport float64 `json:"product_discount_for_report"`
+	SupplierPromo            float64 `json:"supplier_promo"`
+	PpvzSppPrc               float64 `json:"ppvz_spp_prc"`
+	PpvzKvwPrcBase           float64 `json:"ppvz_kvw_prc_base"`
+	PpvzKvwPrc               float64 `json:"ppvz_kvw_prc"`
+	PpvzSalesCommission      float64 `json:"ppvz_sales_commission"`
+	PpvzForPay               float64 `json:"ppvz_for_pay"`
+	PpvzReward               float64 `json:"ppvz_reward"`
+	AcquiringFee             float64 `json:"acquiring_fee"`
+	PpvzVw                   float64 `json:"ppvz_vw"`
+	PpvzVwNds                float64 `json:"ppvz_vw_nds"`
+	PpvzOfficeID             uint64  `json:"ppvz_office_id"`
+	BonusTypeName            string  `json:"bonus_type_name"`
+	StickerID                string  `json:"sticker_id"`
+	SiteCountry              string  `json:"site_country"`
+	Penalty                  float64 `json:"penalty"`
+	AdditionalPayment        float64 `json:"additional_payment"`
+	RebillLogisticCost       float64 `json:"rebill_logistic_cost"`
+	StorageFee               float64 `json:"storage_fee"`
+	Deduction                float64 `json:"deduction"`
+	Acceptance               float64 `json:"acceptance"`
+	Srid                     string  `json:"srid"`
+}
+
+// GetStatisticsReportDetail возвращает детализацию отчетов о реализации за период.
+// Так как за раз можно получить не все строки, выполняются несколько запросов,
+// следующий начинается с rrd_id последней строки. Запросы прекращаются на неполной части
+func (c *Client) GetStatisticsReportDetail(dateFrom string, dateTo string) ([]*StatisticsReportDetail, error) {
+	c.logger.Debug(fmt.Sprintf("Получение детализации отчетов о реализации с %s по %s", dateFrom, dateTo))
+
+	var result []*StatisticsReportDetail
+	var rrdID uint64
+
+	for {
+		var page []*StatisticsReportDetail
+
+		uri := fmt.Sprintf(
+			"%s/%s?dateFrom=%s&dateTo=%s&limit=%d&rrdid=%d",
+			c.baseURL.statistics, statisticsPathReportDetail, dateFrom, dateTo, statisticsReportDetailLimit, rrdID,
+		)
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, &page, statisticsReportsRateLimit); err != nil {
+			return nil, err
+		}
+
+		if len(page) == 0 {
+			break
+		}
+
+		result = append(result, page...)
+		if len(page) < int(statisticsReportDetailLimit) {
+			break
+		}
+		rrdID = page[len(page)-1].RrdID
+	}
+
+	return result, nil
+}
+
+// getStatisticsPages получает данные раздела статистики по частям.
+// Следующая часть запрашивается с lastChangeDate последней строки, пока не будет получена неполная часть.
+// Если flag меньше 0, то параметр не передается
+func getStatisticsPages[T any](c *Client, path string, dateFrom string, flag int, lastChangeDate func(*T) string) ([]*T, error) {
+	var result []*T
+
+	for {
+		var page []*T
+
+		query := url.Values{}
+		query.Set("dateFrom", dateFrom)
+		if flag >= 0 {
+			query.Set("flag", fmt.Sprintf("%d", flag))
+		}
+
+		uri := fmt.Sprintf("%s/%s?%s", c.baseURL.statistics, path, query.Encode())
+
+		if err := c.requestJSON(http.MethodGet, uri, nil, &page, statisticsReportsRateLimit); err != nil {
+			return nil, err
+		}
+
+		result = append(result, page...)
+
+		// При flag равном 1 данные возвращаются одним запросом, в остальных случаях часть содержит
+		// не более statisticsPageLimit строк, и неполная часть последняя
+		if len(page) < statisticsPageLimit || flag == 1 {
+			break
+		}
+
+		next := lastChangeDate(page[len(page)-1])
+		if next == dateFrom {
+			break
+		}
+		dateFrom = next
+	}
+
+	return result, nil
+}
@@ -0,0 +1,161 @@
+package wbapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	suppliesPathAcceptanceCoefficients string = "api/v1/acceptance/coefficients"
+	suppliesPathAcceptanceOptions      string = "api/v1/acceptance/options"
+	suppliesPathWarehouses             string = "api/v1/warehouses"
+	suppliesAcceptanceOptionsLimit     int    = 5000
+)
+
+// Типы упаковки при приемке
+const (
+	BoxTypeBox        uint8 = 2
+	BoxTypeMonopallet uint8 = 5
+	BoxTypeSupersafe  uint8 = 6
+)
+
+// suppliesRateLimit ограничение количества отправленных запросов в минуту.
+// 6 запросов в минуту к api поставок FBW
+var suppliesRateLimit *rateLimit = &rateLimit{interval: time.Second * 10}
+
+// AcceptanceCoefficient описывает коэффициент приемки склада на дату.
+// Коэффициент 0 означает бесплатную приемку, -1 означает, что приемка недоступна
+type AcceptanceCoefficient struct {
+	Date          string  `json:"date"`
+	Coefficient   float64 `json:"coefficient"`
+	WarehouseID   uint32  `json:"warehouseID"`
+	WarehouseName string  `json:"warehouseName"`
+	AllowUnload   bool    `json:"allowUnload"`
+	BoxTypeName   string  `json:"boxTypeName"`
+	BoxTypeID     uint8   `json:"boxTypeID"`
+}
+
+// Available проверяет, доступна ли приемка
+func (a *AcceptanceCoefficient) Available() bool {
+	return a.AllowUnload && a.Coefficient >= 0
+}
+
+// AcceptanceGood описывает товар и его количество для проверки опций приемки
+type AcceptanceGood struct {
+	Quantity uint32 `json:"quantity"`
+	Barcode  string `json:"barcode"`
+}
+
+// AcceptanceWarehouse описывает доступные типы упаковки товара на складе
+type AcceptanceWarehouse struct {
+	WarehouseID   uint32 `json:"warehouseID"`
+	CanBox        bool   `json:"canBox"`
+	CanMonopallet bool   `json:"canMonopallet"`
+	CanSupersafe  bool   `json:"canSupersafe"`
+}
+
+// Can проверяет, принимает ли склад товар в указанном типе упаковки
+func (w *AcceptanceWarehouse) Can(boxTypeID uint8) bool {
+	switch boxTypeID {
+	case BoxTypeBox:
+		return w.CanBox
+	case BoxTypeMonopallet:
+		return w.CanMonopallet
+	case BoxTypeSupersafe:
+		return w.CanSupersafe
+	}
+
+	return false
+}
+
+// AcceptanceOption описывает склады, которые принимают товар
+type AcceptanceOption struct {
+	Barcode string `json:"barcode"`
+	IsError bool   `json:"isError"`
+	Error   *struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"error"`
+	Warehouses []*AcceptanceWarehouse `json:"warehouses"`
+}
+
+// acceptanceOptionsResponse описывает ответ с опциями приемки
+type acceptanceOptionsResponse struct {
+	Result []*AcceptanceOption `json:"result"`
+}
+
+// SuppliesWarehouse описывает склад WB для поставок FBW
+type SuppliesWarehouse struct {
+	ID        uint32 `json:"ID"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+	WorkTime  string `json:"workTime"`
+	AcceptsQR bool   `json:"acceptsQR"`
+}
+
+// GetAcceptanceCoefficients возвращает коэффициенты приемки складов на ближайшие 14 дней.
+// Если список складов пустой, то возвращаются коэффициенты всех складов
+func (c *Client) GetAcceptanceCoefficients(warehouseIDs []uint32) ([]*AcceptanceCoefficient, error) {
+	c.logger.Debug("Получение коэффициентов приемки")
+
+	var coefficients []*AcceptanceCoefficient
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.supplies, suppliesPathAcceptanceCoefficients)
+	if len(warehouseIDs) > 0 {
+		var ids []string
+		for _, id := range warehouseIDs {
+			ids = append(ids, fmt.Sprintf("%d", id))
+		}
+
+		query := url.Values{}
+		query.Set("warehouseIDs", strings.Join(ids, ","))
+		uri = fmt.Sprintf("%s?%s", uri, query.Encode())
+	}
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, &coefficients, suppliesRateLimit); err != nil {
+		return nil, err
+	}
+
+	return coefficients, nil
+}
+
+// GetAcceptanceOptions возвращает склады, которые принимают товары.
+// Опции запрашиваются по 5000 товаров
+func (c *Client) GetAcceptanceOptions(goods []*AcceptanceGood) ([]*AcceptanceOption, error) {
+	c.logger.Debug(fmt.Sprintf("Получение опций приемки для %d товаров", len(goods)))
+
+	var options []*AcceptanceOption
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.supplies, suppliesPathAcceptanceOptions)
+
+	for i := 0; i < len(goods); i += suppliesAcceptanceOptionsLimit {
+		page := &acceptanceOptionsResponse{}
+
+		chunk := goods[i:min(i+suppliesAcceptanceOptionsLimit, len(goods))]
+		if err := c.requestJSON(http.MethodPost, uri, chunk, page, suppliesRateLimit); err != nil {
+			return nil, err
+		}
+
+		options = append(options, page.Result...)
+	}
+
+	return options, nil
+}
+
+// GetSuppliesWarehouses возвращает список складов WB для поставок FBW
+func (c *Client) GetSuppliesWarehouses() ([]*SuppliesWarehouse, error) {
+	c.logger.Debug("Получение списка складов для поставок")
+
+	var warehouses []*SuppliesWarehouse
+
+	uri := fmt.Sprintf("%s/%s", c.baseURL.supplies, suppliesPathWarehouses)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, &warehouses, suppliesRateLimit); err != nil {
+		return nil, err
+	}
+
+	return warehouses, nil
+}
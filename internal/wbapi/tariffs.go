@@ -0,0 +1,163 @@
+package wbapi
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	commonPathTariffsBox        string = "api/v1/tariffs/box"
+	commonPathTariffsPallet     string = "api/v1/tariffs/pallet"
+	commonPathTariffsReturn     string = "api/v1/tariffs/return"
+	commonPathTariffsCommission string = "api/v1/tariffs/commission"
+)
+
+// tariffsRateLimit ограничение количества отправленных запросов в минуту.
+// 60 запросов в минуту к тарифам коробов, монопаллет и возвратов
+var tariffsRateLimit *rateLimit = &rateLimit{interval: time.Second}
+
+// commissionRateLimit ограничение количества отправленных запросов в минуту.
+// 1 запрос в минуту к комиссиям
+var commissionRateLimit *rateLimit = &rateLimit{interval: time.Minute}
+
+// Tariff значение тарифа. В api тарифы передаются строкой с запятой в качестве разделителя дробной части,
+// отсутствующий тариф передается как "-"
+type Tariff string
+
+// Value возвращает значение тарифа или nil, если тариф не задан.
+// Пробелы, в том числе неразрывные, которыми разделяются разряды, удаляются
+func (t Tariff) Value() *float64 {
+	value := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, strings.ReplaceAll(string(t), ",", "."))
+
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+
+	return &v
+}
+
+// BoxTariff описывает тарифы для коробов на складе
+type BoxTariff struct {
+	WarehouseName             string `json:"warehouseName"`
+	GeoName                   string `json:"geoName"`
+	BoxDeliveryAndStorageExpr Tariff `json:"boxDeliveryAndStorageExpr"`
+	BoxDeliveryBase           Tariff `json:"boxDeliveryBase"`
+	BoxDeliveryLiter          Tariff `json:"boxDeliveryLiter"`
+	BoxStorageBase            Tariff `json:"boxStorageBase"`
+	BoxStorageLiter           Tariff `json:"boxStorageLiter"`
+}
+
+// PalletTariff описывает тарифы для монопаллет на складе
+type PalletTariff struct {
+	WarehouseName            string `json:"warehouseName"`
+	PalletDeliveryExpr       Tariff `json:"palletDeliveryExpr"`
+	PalletDeliveryValueBase  Tariff `json:"palletDeliveryValueBase"`
+	PalletDeliveryValueLiter Tariff `json:"palletDeliveryValueLiter"`
+	PalletStorageExpr        Tariff `json:"palletStorageExpr"`
+	PalletStorageValueExpr   Tariff `json:"palletStorageValueExpr"`
+}
+
+// ReturnTariff описывает тарифы на возврат товаров продавцу со склада
+type ReturnTariff struct {
+	WarehouseName               string `json:"warehouseName"`
+	DeliveryDumpKgtOfficeBase   Tariff `json:"deliveryDumpKgtOfficeBase"`
+	DeliveryDumpKgtOfficeLiter  Tariff `json:"deliveryDumpKgtOfficeLiter"`
+	DeliveryDumpKgtReturnExpr   Tariff `json:"deliveryDumpKgtReturnExpr"`
+	DeliveryDumpSrgOfficeExpr   Tariff `json:"deliveryDumpSrgOfficeExpr"`
+	DeliveryDumpSrgReturnExpr   Tariff `json:"deliveryDumpSrgReturnExpr"`
+	DeliveryDumpSupCourierBase  Tariff `json:"deliveryDumpSupCourierBase"`
+	DeliveryDumpSupCourierLiter Tariff `json:"deliveryDumpSupCourierLiter"`
+	DeliveryDumpSupOfficeBase   Tariff `json:"deliveryDumpSupOfficeBase"`
+	DeliveryDumpSupOfficeLiter  Tariff `json:"deliveryDumpSupOfficeLiter"`
+	DeliveryDumpSupReturnExpr   Tariff `json:"deliveryDumpSupReturnExpr"`
+}
+
+// Commission описывает комиссию по предмету в процентах
+type Commission struct {
+	SubjectID           uint32  `json:"subjectID"`
+	SubjectName         string  `json:"subjectName"`
+	ParentID            uint32  `json:"parentID"`
+	ParentName          string  `json:"parentName"`
+	KgvpMarketplace     float64 `json:"kgvpMarketplace"`
+	KgvpSupplier        float64 `json:"kgvpSupplier"`
+	KgvpSupplierExpress float64 `json:"kgvpSupplierExpress"`
+	PaidStorageKgvp     float64 `json:"paidStorageKgvp"`
+}
+
+// tariffsResponse описывает ответ со списком тарифов по складам
+type tariffsResponse[T any] struct {
+	Response struct {
+		Data struct {
+			WarehouseList []*T `json:"warehouseList"`
+		} `json:"data"`
+	} `json:"response"`
+}
+
+// commissionResponse описывает ответ со списком комиссий
+type commissionResponse struct {
+	Report []*Commission `json:"report"`
+}
+
+// GetBoxTariffs возвращает тарифы для коробов на дату
+func (c *Client) GetBoxTariffs(date string) ([]*BoxTariff, error) {
+	c.logger.Debug(fmt.Sprintf("Получение тарифов для коробов на %s", date))
+
+	return getTariffs[BoxTariff](c, commonPathTariffsBox, date)
+}
+
+// GetPalletTariffs возвращает тарифы для монопаллет на дату
+func (c *Client) GetPalletTariffs(date string) ([]*PalletTariff, error) {
+	c.logger.Debug(fmt.Sprintf("Получение тарифов для монопаллет на %s", date))
+
+	return getTariffs[PalletTariff](c, commonPathTariffsPallet, date)
+}
+
+// GetReturnTariffs возвращает тарифы на возврат товаров на дату
+func (c *Client) GetReturnTariffs(date string) ([]*ReturnTariff, error) {
+	c.logger.Debug(fmt.Sprintf("Получение тарифов на возврат на %s", date))
+
+	return getTariffs[ReturnTariff](c, commonPathTariffsReturn, date)
+}
+
+// getTariffs возвращает тарифы по складам на дату
+func getTariffs[T any](c *Client, path string, date string) ([]*T, error) {
+	tariffs := &tariffsResponse[T]{}
+
+	query := url.Values{}
+	query.Set("date", date)
+
+	uri := fmt.Sprintf("%s/%s?%s", c.baseURL.common, path, query.Encode())
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, tariffs, tariffsRateLimit); err != nil {
+		return nil, err
+	}
+
+	return tariffs.Response.Data.WarehouseList, nil
+}
+
+// GetCommissions возвращает комиссии по предметам
+func (c *Client) GetCommissions() ([]*Commission, error) {
+	c.logger.Debug("Получение комиссий по предметам")
+
+	commissions := &commissionResponse{}
+
+	uri := fmt.Sprintf("%s/%s?locale=ru", c.baseURL.common, commonPathTariffsCommission)
+
+	if err := c.requestJSON(http.MethodGet, uri, nil, commissions, commissionRateLimit); err != nil {
+		return nil, err
+	}
+
+	return commissions.Report, nil
+}
@@ -0,0 +1,46 @@
+package wbapi
+
+import (
+	"testing"
+)
+
+func TestTariffValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		tariff Tariff
+		want   *float64
+	}{
+		{name: "целое", tariff: "48", want: ptr(48)},
+		{name: "дробная часть через запятую", tariff: "1,25", want: ptr(1.25)},
+		{name: "дробная часть через точку", tariff: "1.25", want: ptr(1.25)},
+		{name: "ноль", tariff: "0", want: ptr(0)},
+		{name: "ноль с дробной частью", tariff: "0,00", want: ptr(0)},
+		{name: "отрицательное", tariff: "-1,5", want: ptr(-1.5)},
+		{name: "разделитель тысяч пробел", tariff: "1 234,5", want: ptr(1234.5)},
+		{name: "разделитель тысяч неразрывный пробел", tariff: "1\u00a0234,5", want: ptr(1234.5)},
+		{name: "пробелы по краям", tariff: " 12,5 ", want: ptr(12.5)},
+		{name: "прочерк", tariff: "-", want: nil},
+		{name: "пустая строка", tariff: "", want: nil},
+		{name: "текст", tariff: "нет", want: nil},
+		{name: "NaN", tariff: "NaN", want: nil},
+		{name: "бесконечность", tariff: "Inf", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tariff.Value()
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("получено %v, ожидалось отсутствие тарифа", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("получено отсутствие тарифа, ожидалось %v", *tt.want)
+			case tt.want != nil && *got != *tt.want:
+				t.Errorf("получено %v, ожидалось %v", *got, *tt.want)
+			}
+		})
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
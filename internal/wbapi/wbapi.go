@@ -2,10 +2,12 @@ package wbapi
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -14,6 +16,33 @@ type Client struct {
 	token   string
 	baseURL *ClientBaseURL
 	logger  *slog.Logger
+	tickers *rateTickers
+}
+
+// rateLimit описывает ограничение WB на количество запросов: не чаще одного запроса за interval
+type rateLimit struct {
+	interval time.Duration
+}
+
+// rateTickers хранит тикеры ограничений запросов клиента.
+// WB ограничивает количество запросов для каждого продавца, поэтому тикеры у каждого клиента свои
+type rateTickers struct {
+	mu      sync.Mutex
+	tickers map[*rateLimit]<-chan time.Time
+}
+
+// wait ожидает, когда можно будет отправить запрос с ограничением limit.
+// Тикер ограничения создается при первом запросе
+func (t *rateTickers) wait(limit *rateLimit) {
+	t.mu.Lock()
+	ch, ok := t.tickers[limit]
+	if !ok {
+		ch = time.NewTicker(limit.interval).C
+		t.tickers[limit] = ch
+	}
+	t.mu.Unlock()
+
+	<-ch
 }
 
 // ClientOptions интерфейс дополнительных опций клиента
@@ -32,6 +61,16 @@ type ClientBaseURL struct {
 	content     string
 	marketplace string
 	statistics  string
+	analytics   string
+	feedbacks   string
+	advert      string
+	common      string
+	supplies    string
+	returns     string
+	chat        string
+	documents   string
+	finance     string
+	calendar    string
 }
 
 // SetClientBaseURL задает базовые URL
@@ -53,6 +92,16 @@ var defaultClientBaseURL *ClientBaseURL = &ClientBaseURL{
 	content:     "https://content-api.wildberries.ru",
 	marketplace: "https://marketplace-api.wildberries.ru",
 	statistics:  "https://statistics-api.wildberries.ru",
+	analytics:   "https://seller-analytics-api.wildberries.ru",
+	feedbacks:   "https://feedbacks-api.wildberries.ru",
+	advert:      "https://advert-api.wildberries.ru",
+	common:      "https://common-api.wildberries.ru",
+	supplies:    "https://supplies-api.wildberries.ru",
+	returns:     "https://returns-api.wildberries.ru",
+	chat:        "https://buyer-chat-api.wildberries.ru",
+	documents:   "https://documents-api.wildberries.ru",
+	finance:     "https://finance-api.wildberries.ru",
+	calendar:    "https://dp-calendar-api.wildberries.ru",
 }
 
 // NewClient создает клиента подключения
@@ -66,6 +115,7 @@ func NewClientWithOptions(token string, opts ...ClientOptions) *Client {
 		token:   token,
 		baseURL: defaultClientBaseURL,
 		logger:  slog.New(&slog.TextHandler{}),
+		tickers: &rateTickers{tickers: make(map[*rateLimit]<-chan time.Time)},
 	}
 
 	for _, opt := range opts {
@@ -119,7 +169,7 @@ func (c Client) contentPing() error {
 
 	url := fmt.Sprintf("%s/%s", c.baseURL.content, contentPathPing)
 
-	return c.requestPing(url, contentRequestTicker)
+	return c.requestPing(url, contentRateLimit)
 }
 
 // marketplacePing проверяет доступность API Marketplace
@@ -128,7 +178,7 @@ func (c Client) marketplacePing() error {
 
 	url := fmt.Sprintf("%s/%s", c.baseURL.marketplace, marketplacePathPing)
 
-	return c.requestPing(url, marketplaceRequestTicker)
+	return c.requestPing(url, marketplaceRateLimit)
 }
 
 // statisticsPing проверяет доступность API Statistics
@@ -137,12 +187,12 @@ func (c Client) statisticsPing() error {
 
 	url := fmt.Sprintf("%s/%s", c.baseURL.statistics, statisticsPathPing)
 
-	return c.requestPing(url, statisticsRequestTicker)
+	return c.requestPing(url, statisticsRateLimit)
 }
 
 // requestPing делает запрос ping к указанному ресурсу
-func (c Client) requestPing(url string, ch <-chan time.Time) error {
-	res, err := c.getRequest(url, ch)
+func (c Client) requestPing(url string, limit *rateLimit) error {
+	res, err := c.getRequest(url, limit)
 	if err != nil {
 		return err
 	}
@@ -161,15 +211,35 @@ func (c Client) requestPing(url string, ch <-chan time.Time) error {
 // postRequest делает POST запрос обогащенный заголовками
 // В ответе получаем http.Response без обработки
 // Если запрос возвращает code 429, то запрос повторяется через некоторое время
-func (c Client) postRequest(uri string, data []byte, ch <-chan time.Time) (*http.Response, error) {
+func (c Client) postRequest(uri string, data []byte, limit *rateLimit) (*http.Response, error) {
+	return c.request(http.MethodPost, uri, data, limit)
+}
+
+// getRequest делает Get запрос обогащенный заголовками
+// В ответе получаем http.Response без обработки
+// Если запрос возвращает code 429, то запрос повторяется через некоторое время
+func (c Client) getRequest(url string, limit *rateLimit) (*http.Response, error) {
+	return c.request(http.MethodGet, url, nil, limit)
+}
+
+// request делает запрос указанного типа обогащенный заголовками
+// В ответе получаем http.Response без обработки
+// Если запрос возвращает code 429, то запрос повторяется через некоторое время
+func (c Client) request(method string, uri string, data []byte, limit *rateLimit) (*http.Response, error) {
+	return c.requestWithContentType(method, uri, data, "application/json", limit)
+}
+
+// requestWithContentType делает запрос как request, но с указанным типом содержимого тела
+func (c Client) requestWithContentType(method string, uri string, data []byte, contentType string, limit *rateLimit) (*http.Response, error) {
 	var delay time.Duration = 30
 	for {
-		req, err := http.NewRequest("POST", uri, bytes.NewBuffer(data))
+		req, err := http.NewRequest(method, uri, bytes.NewReader(data))
 		if err != nil {
 			return nil, err
 		}
+		req.Header.Set("Content-Type", contentType)
 
-		<-ch
+		c.tickers.wait(limit)
 
 		res, err := c.httpRequest(req)
 		if err != nil {
@@ -180,44 +250,51 @@ func (c Client) postRequest(uri string, data []byte, ch <-chan time.Time) (*http
 			return res, nil
 		}
 
+		res.Body.Close()
+
 		c.logger.Debug(fmt.Sprintf("Получен статус ответа %s. Ожидание %d секунд", res.Status, delay))
 		time.Sleep(delay * time.Second)
 		delay += 30
 	}
 }
 
-// getRequest делает Get запрос обогащенный заголовками
-// В ответе получаем http.Response без обработки
-// Если запрос возвращает code 429, то запрос повторяется через некоторое время
-func (c Client) getRequest(url string, ch <-chan time.Time) (*http.Response, error) {
-	var delay time.Duration = 30
-	for {
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
+// requestJSON делает запрос с телом body в формате JSON и декодирует ответ в result.
+// Если body равен nil, то запрос отправляется без тела.
+// Если result равен nil или ответ не содержит данных (код 204), то тело ответа не обрабатывается
+func (c Client) requestJSON(method string, url string, body any, result any, limit *rateLimit) error {
+	var data []byte
 
-		<-ch
-
-		res, err := c.httpRequest(req)
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		data = jsonBody
+	}
 
-		if res.StatusCode != 429 {
-			return res, nil
-		}
+	res, err := c.request(method, url, data, limit)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
 
-		c.logger.Debug(fmt.Sprintf("Получен статус ответа %s. Ожидание %d секунд", res.Status, delay))
-		time.Sleep(delay * time.Second)
-		delay += 30
+	if err := respCodeCheck(res); err != nil {
+		return err
 	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
 }
 
 // httpRequest делает запрос к API.
 // Тип запроса определяется во входящем параметре.
 func (c Client) httpRequest(req *http.Request) (*http.Response, error) {
-	req.Header.Add("Content-Type", "application/json")
+	if req.Header.Get("Content-Type") == "" {
+		req.Header.Add("Content-Type", "application/json")
+	}
 	req.Header.Add("Authorization", c.token)
 
 	client := &http.Client{}
@@ -227,13 +304,11 @@ func (c Client) httpRequest(req *http.Request) (*http.Response, error) {
 	return client.Do(req)
 }
 
-// respCodeCheck проверяет HTTP ответ на коды отличные от 200
+// respCodeCheck проверяет HTTP ответ на коды отличные от 2xx
 func respCodeCheck(res *http.Response) error {
-	var buf bytes.Buffer
-	tee := io.TeeReader(res.Body, &buf)
-
-	if res.StatusCode != 200 {
-		return fmt.Errorf("code: %d, body: %s", res.StatusCode, tee)
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("code: %d, body: %s", res.StatusCode, body)
 	}
 
 	return nil